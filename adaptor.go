@@ -3,6 +3,8 @@ package strconvx
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/ggicci/strconvx/internal"
 )
 
 type Adaptor[T any] func(*T) (StringCodec, error)
@@ -18,9 +20,149 @@ func ToAnyAdaptor[T any](adaptor Adaptor[T]) (reflect.Type, AnyAdaptor) {
 	}
 }
 
-var builtinAdaptors = make(map[reflect.Type]AnyAdaptor)
+// builtinAnyAdaptor is similar to AnyAdaptor, but it also receives the options
+// passed to New, so that the builtin types can be converted in a configurable way.
+type builtinAnyAdaptor func(any, *options) (StringCodec, error)
+
+var builtinAdaptors = make(map[reflect.Type]builtinAnyAdaptor)
 
 func builtinAdaptor[T any](adaptor Adaptor[T]) {
-	typ, anyAdaptor := ToAnyAdaptor(adaptor)
-	builtinAdaptors[typ] = anyAdaptor
+	builtinAdaptorWithOptions(func(v *T, _ *options) (StringCodec, error) {
+		return adaptor(v)
+	})
+}
+
+func builtinAdaptorWithOptions[T any](adaptor func(*T, *options) (StringCodec, error)) {
+	builtinAdaptors[typeOf[T]()] = func(v any, o *options) (StringCodec, error) {
+		if cv, ok := v.(*T); ok {
+			return adaptor(cv, o)
+		} else {
+			return nil, fmt.Errorf("%w: cannot convert %T to %s", ErrTypeMismatch, v, typeOf[*T]())
+		}
+	}
+}
+
+// builtinKindTypes maps the kinds which have a builtin adaptor to the
+// corresponding builtin types.
+var builtinKindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:     typeOf[string](),
+	reflect.Bool:       typeOf[bool](),
+	reflect.Int:        typeOf[int](),
+	reflect.Int8:       typeOf[int8](),
+	reflect.Int16:      typeOf[int16](),
+	reflect.Int32:      typeOf[int32](),
+	reflect.Int64:      typeOf[int64](),
+	reflect.Uint:       typeOf[uint](),
+	reflect.Uint8:      typeOf[uint8](),
+	reflect.Uint16:     typeOf[uint16](),
+	reflect.Uint32:     typeOf[uint32](),
+	reflect.Uint64:     typeOf[uint64](),
+	reflect.Float32:    typeOf[float32](),
+	reflect.Float64:    typeOf[float64](),
+	reflect.Complex64:  typeOf[complex64](),
+	reflect.Complex128: typeOf[complex128](),
+}
+
+// underlyingBuiltinType returns the builtin type that shares the same
+// underlying type with typ, e.g. int64 for "type UserID int64", []byte for
+// "type Hash []byte". Returns nil if there's no such builtin type.
+func underlyingBuiltinType(typ reflect.Type) reflect.Type {
+	var bt reflect.Type
+	if typ.Kind() == reflect.Slice {
+		bt = typeOf[[]byte]()
+	} else {
+		bt = builtinKindTypes[typ.Kind()]
+	}
+	// A pointer to typ must be convertible to a pointer to bt, so that the
+	// builtin adaptor can operate on the original value in place.
+	if bt == nil || !reflect.PointerTo(typ).ConvertibleTo(reflect.PointerTo(bt)) {
+		return nil
+	}
+	return bt
+}
+
+// namedCodec wraps the builtin StringCodec of the underlying type of a named
+// type, e.g. "type UserID int64", to report the named type in ConvErrors.
+type namedCodec struct {
+	StringCodec
+	typ reflect.Type
+}
+
+func (nc *namedCodec) ToString() (string, error) {
+	s, err := nc.StringCodec.ToString()
+	return s, nc.retype(err)
+}
+
+func (nc *namedCodec) AppendString(dst []byte) ([]byte, error) {
+	dst, err := appendString(dst, nc.StringCodec)
+	return dst, nc.retype(err)
+}
+
+func (nc *namedCodec) FromString(s string) error {
+	return nc.retype(nc.StringCodec.FromString(s))
+}
+
+func (nc *namedCodec) retype(err error) error {
+	if ce, ok := err.(*ConvError); ok {
+		ce.Type = nc.typ
+	}
+	return err
+}
+
+// signedAdaptor creates the builtin adaptor of a signed integer type, which
+// uses the given StringCodec of the type by default, and the configured one
+// with the integer options, see IntBase, IntFormatBase and ClampOverflow.
+func signedAdaptor[T internal.Signed](codec func(*T) StringCodec) func(*T, *options) (StringCodec, error) {
+	return func(v *T, o *options) (StringCodec, error) {
+		if !o.hasIntOptions() {
+			return codec(v), nil
+		}
+		if err := o.validateIntBases(); err != nil {
+			return nil, err
+		}
+		return &internal.IntWithOptions[T]{
+			Value:      v,
+			ParseBase:  o.IntBase,
+			FormatBase: o.IntFormatBase,
+			Clamp:      o.Has(optionClampOverflow),
+		}, nil
+	}
+}
+
+// unsignedAdaptor is the unsigned version of signedAdaptor.
+func unsignedAdaptor[T internal.Unsigned](codec func(*T) StringCodec) func(*T, *options) (StringCodec, error) {
+	return func(v *T, o *options) (StringCodec, error) {
+		if !o.hasIntOptions() {
+			return codec(v), nil
+		}
+		if err := o.validateIntBases(); err != nil {
+			return nil, err
+		}
+		return &internal.UintWithOptions[T]{
+			Value:      v,
+			ParseBase:  o.IntBase,
+			FormatBase: o.IntFormatBase,
+			Clamp:      o.Has(optionClampOverflow),
+		}, nil
+	}
+}
+
+// floatAdaptor creates the builtin adaptor of a floating-point type, which
+// uses the given StringCodec of the type by default, and the configured one
+// with the float options, see FloatFormat and RejectNonFinite.
+func floatAdaptor[T internal.Float](codec func(*T) StringCodec) func(*T, *options) (StringCodec, error) {
+	return func(v *T, o *options) (StringCodec, error) {
+		if !o.hasFloatOptions() {
+			return codec(v), nil
+		}
+		if err := o.validateFloatFormat(); err != nil {
+			return nil, err
+		}
+		return &internal.FloatWithOptions[T]{
+			Value:           v,
+			Format:          o.FloatFormat,
+			Precision:       o.FloatPrecision,
+			RejectNonFinite: o.Has(optionRejectNonFinite),
+		}, nil
+	}
 }
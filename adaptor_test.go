@@ -51,3 +51,18 @@ func TestToAnyAdaptor(t *testing.T) {
 	assert.Nil(t, codec)
 	assert.ErrorContains(t, err, "cannot convert *int to *bool")
 }
+
+func TestUnderlyingBuiltinType(t *testing.T) {
+	type UserID int64
+	type Hash []byte
+	type Score float64
+	type Bytes []YesNo
+
+	assert.Equal(t, typeOf[int64](), underlyingBuiltinType(typeOf[UserID]()))
+	assert.Equal(t, typeOf[[]byte](), underlyingBuiltinType(typeOf[Hash]()))
+	assert.Equal(t, typeOf[float64](), underlyingBuiltinType(typeOf[Score]()))
+	assert.Equal(t, typeOf[bool](), underlyingBuiltinType(typeOf[YesNo]()))
+	assert.Nil(t, underlyingBuiltinType(typeOf[Bytes]()))
+	assert.Nil(t, underlyingBuiltinType(typeOf[[]int]()))
+	assert.Nil(t, underlyingBuiltinType(typeOf[StructNotStringConvertable]()))
+}
@@ -0,0 +1,49 @@
+package strconvx
+
+import (
+	"fmt"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
+// BoolWordSet is a pair of the words of true and false, see BoolWords and
+// BoolOutput.
+type BoolWordSet = internal.BoolWords
+
+// The common word sets of booleans.
+var (
+	BoolYesNo           = BoolWordSet{True: "yes", False: "no"}
+	BoolOnOff           = BoolWordSet{True: "on", False: "off"}
+	BoolYN              = BoolWordSet{True: "y", False: "n"}
+	BoolEnabledDisabled = BoolWordSet{True: "enabled", False: "disabled"}
+)
+
+// createBoolStringCodec creates the StringCodec of bool.
+func createBoolStringCodec(v *bool, o *options) (StringCodec, error) {
+	if o.BoolWords == nil && o.BoolOutput == (BoolWordSet{}) {
+		return (*internal.Bool)(v), nil
+	}
+	for _, words := range o.BoolWords {
+		if err := validateBoolWordSet(words); err != nil {
+			return nil, err
+		}
+	}
+	if o.BoolOutput != (BoolWordSet{}) {
+		if err := validateBoolWordSet(o.BoolOutput); err != nil {
+			return nil, err
+		}
+	}
+	return &internal.BoolWithOptions{
+		Value:         v,
+		Words:         o.BoolWords,
+		Output:        o.BoolOutput,
+		CaseSensitive: o.Has(optionBoolCaseSensitive),
+	}, nil
+}
+
+func validateBoolWordSet(words BoolWordSet) error {
+	if words.True == "" || words.False == "" || words.True == words.False {
+		return fmt.Errorf("invalid boolean words: %q/%q", words.True, words.False)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package strconvx
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/ggicci/strconvx/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoolWords(t *testing.T) {
+	ns := NewNamespace()
+	ns.SetOptions(BoolWords(BoolYesNo, BoolOnOff, BoolYN, BoolEnabledDisabled))
+
+	testcases := []struct {
+		input    string
+		expected bool
+	}{
+		{"yes", true},
+		{"No", false},
+		{"ON", true},
+		{"off", false},
+		{"y", true},
+		{"N", false},
+		{"enabled", true},
+		{"Disabled", false},
+		// The words of strconv.ParseBool are still accepted.
+		{"true", true},
+		{"0", false},
+	}
+
+	for _, c := range testcases {
+		var b bool
+		sv, err := ns.New(&b)
+		assert.NoError(t, err)
+		assert.NoError(t, sv.FromString(c.input), c.input)
+		assert.Equal(t, c.expected, b, c.input)
+		assert.Equal(t, map[bool]string{true: "true", false: "false"}[c.expected], must(sv.ToString()))
+	}
+
+	var b bool
+	sv, err := ns.New(&b)
+	assert.NoError(t, err)
+	err = sv.FromString("maybe")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[bool](), ce.Type)
+}
+
+func TestBoolWords_Default(t *testing.T) {
+	var b bool
+	sv, err := NewNamespace().New(&b)
+	assert.NoError(t, err)
+	assert.IsType(t, (*internal.Bool)(nil), sv)
+	assert.Error(t, sv.FromString("yes"))
+}
+
+func TestBoolOutput(t *testing.T) {
+	b := true
+	sv, err := NewNamespace().New(&b, BoolOutput("yes", "no"))
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", must(sv.ToString()))
+	buf, err := sv.(StringAppender).AppendString([]byte("enabled="))
+	assert.NoError(t, err)
+	assert.Equal(t, "enabled=yes", string(buf))
+
+	// The output words can be parsed back.
+	assert.NoError(t, sv.FromString("no"))
+	assert.False(t, b)
+	assert.Equal(t, "no", must(sv.ToString()))
+	assert.NoError(t, sv.FromString("YES"))
+	assert.True(t, b)
+}
+
+func TestBoolCaseSensitive(t *testing.T) {
+	var b bool
+	sv, err := NewNamespace().New(&b, BoolWords(BoolOnOff), BoolCaseSensitive())
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("on"))
+	assert.True(t, b)
+	assert.Error(t, sv.FromString("OFF"))
+	assert.True(t, b)
+	assert.NoError(t, sv.FromString("FALSE"))
+	assert.False(t, b)
+}
+
+func TestBoolWords_Invalid(t *testing.T) {
+	for _, opt := range []Option{
+		BoolWords(BoolWordSet{True: "yes"}),
+		BoolWords(BoolWordSet{True: "x", False: "x"}),
+		BoolOutput("", "no"),
+	} {
+		var b bool
+		_, err := NewNamespace().New(&b, opt)
+		assert.ErrorContains(t, err, "invalid boolean words")
+	}
+}
+
+func TestBoolWords_NamedType(t *testing.T) {
+	type Switch bool
+	var s Switch
+	sv, err := NewNamespace().New(&s, BoolWords(BoolOnOff), BoolOutput("on", "off"))
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("on"))
+	assert.Equal(t, Switch(true), s)
+	assert.Equal(t, "on", must(sv.ToString()))
+
+	err = sv.FromString("maybe")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[Switch](), ce.Type)
+}
+
+func TestBoolWords_IsBoolFlag(t *testing.T) {
+	ns := NewNamespace()
+	ns.SetOptions(BoolWords(BoolYesNo), BoolOutput("yes", "no"))
+
+	var debug, verbose bool
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	for name, v := range map[string]*bool{"debug": &debug, "v": &verbose} {
+		codec, err := ns.New(v)
+		assert.NoError(t, err)
+		fs.Var(FlagValue(codec), name, "")
+	}
+	assert.True(t, fs.Lookup("debug").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+
+	assert.NoError(t, fs.Parse([]string{"-debug", "-v=yes"}))
+	assert.True(t, debug)
+	assert.True(t, verbose)
+	assert.Equal(t, "yes", fs.Lookup("debug").Value.String())
+}
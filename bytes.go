@@ -0,0 +1,64 @@
+package strconvx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
+// BinaryEncoding is a binary-to-text encoding of []byte and [N]byte values,
+// e.g. base64.URLEncoding, base32.StdEncoding and HexEncoding, see
+// BytesEncoding.
+type BinaryEncoding = internal.Encoding
+
+// HexEncoding is the hexadecimal BinaryEncoding. It outputs lower case
+// letters, and accepts both cases.
+var HexEncoding BinaryEncoding = internal.Hex{}
+
+var byteType = typeOf[byte]()
+
+// byteArrayCodec is a StringCodec of byte arrays, e.g. [32]byte, which are
+// converted as a whole with the BytesEncoding, instead of element by element.
+type byteArrayCodec struct {
+	rv   reflect.Value // the array
+	opts *options
+}
+
+func createByteArrayStringCodec(rv reflect.Value, opts *options) (StringCodec, error) {
+	return &byteArrayCodec{rv: rv.Elem(), opts: opts}, nil
+}
+
+func (bc *byteArrayCodec) ToString() (string, error) {
+	return bc.opts.BytesEncoding.EncodeToString(bc.rv.Bytes()), nil
+}
+
+func (bc *byteArrayCodec) AppendString(dst []byte) ([]byte, error) {
+	return internal.AppendEncode(bc.opts.BytesEncoding, dst, bc.rv.Bytes()), nil
+}
+
+func (bc *byteArrayCodec) FromString(s string) error {
+	b, err := internal.DecodeString(bc.opts.BytesEncoding, s, bc.opts.Has(optionLenientBytes))
+	if err != nil {
+		return internal.FromStringError(bc.rv.Type(), s, err)
+	}
+	if len(b) != bc.rv.Len() {
+		err := fmt.Errorf("%w: expected %d bytes, got %d", ErrLengthMismatch, bc.rv.Len(), len(b))
+		return internal.FromStringError(bc.rv.Type(), s, err)
+	}
+	reflect.Copy(bc.rv, reflect.ValueOf(b))
+	return nil
+}
+
+// createByteSliceStringCodec creates the StringCodec of []byte.
+func createByteSliceStringCodec(v *[]byte, o *options) (StringCodec, error) {
+	if o.BytesEncoding == nil && !o.Has(optionLenientBytes) {
+		return (*internal.ByteSlice)(v), nil
+	}
+	enc := o.BytesEncoding
+	if enc == nil {
+		enc = base64.StdEncoding
+	}
+	return &internal.ByteSliceWithEncoding{Value: v, Encoding: enc, Lenient: o.Has(optionLenientBytes)}, nil
+}
@@ -0,0 +1,124 @@
+package strconvx
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ggicci/strconvx/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytesEncoding(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef, 0xfb, 0xff}
+	testcases := []struct {
+		encoding BinaryEncoding
+		encoded  string
+	}{
+		{HexEncoding, "deadbeeffbff"},
+		{base64.StdEncoding, "3q2+7/v/"},
+		{base64.URLEncoding, "3q2-7_v_"},
+		{base64.RawURLEncoding, "3q2-7_v_"},
+		{base32.StdEncoding, "32W3537374======"},
+		{base32.HexEncoding, "RQMRTRVRVS======"},
+	}
+
+	for _, c := range testcases {
+		var b []byte
+		sv, err := NewNamespace().New(&b, BytesEncoding(c.encoding))
+		assert.NoError(t, err)
+		assert.NoError(t, sv.FromString(c.encoded))
+		assert.Equal(t, data, b)
+		assert.Equal(t, c.encoded, must(sv.ToString()))
+		buf, err := sv.(StringAppender).AppendString([]byte("0x"))
+		assert.NoError(t, err)
+		assert.Equal(t, "0x"+c.encoded, string(buf))
+	}
+}
+
+func TestBytesEncoding_Default(t *testing.T) {
+	var b []byte
+	sv, err := NewNamespace().New(&b)
+	assert.NoError(t, err)
+	assert.IsType(t, (*internal.ByteSlice)(nil), sv)
+}
+
+func TestBytesEncoding_Error(t *testing.T) {
+	var b = []byte("hello")
+	sv, err := NewNamespace().New(&b, BytesEncoding(HexEncoding))
+	assert.NoError(t, err)
+
+	err = sv.FromString("xyz")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[[]byte](), ce.Type)
+	assert.Equal(t, []byte("hello"), b)
+}
+
+func TestLenientBytes(t *testing.T) {
+	var b []byte
+	sv, err := NewNamespace().New(&b, BytesEncoding(base64.URLEncoding))
+	assert.NoError(t, err)
+	assert.Error(t, sv.FromString("aGVsbG8"))
+	assert.Error(t, sv.FromString("aGVs bG8="))
+
+	sv, err = NewNamespace().New(&b, BytesEncoding(base64.URLEncoding), LenientBytes())
+	assert.NoError(t, err)
+	for _, s := range []string{"aGVsbG8=", "aGVsbG8", " aGVs\nbG8= "} {
+		b = nil
+		assert.NoError(t, sv.FromString(s), s)
+		assert.Equal(t, []byte("hello"), b, s)
+	}
+	// The output is still padded.
+	assert.Equal(t, "aGVsbG8=", must(sv.ToString()))
+
+	// Works with the default encoding as well.
+	sv, err = NewNamespace().New(&b, LenientBytes())
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("d29y\nbGQ"))
+	assert.Equal(t, []byte("world"), b)
+
+	sv, err = NewNamespace().New(&b, BytesEncoding(HexEncoding), LenientBytes())
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("DE AD\tbe ef"))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+}
+
+func TestBytesEncoding_ByteArray(t *testing.T) {
+	type Digest [4]byte
+	var digest Digest
+	ns := NewNamespace()
+	ns.SetOptions(BytesEncoding(HexEncoding))
+	sv, err := ns.New(&digest)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("deadbeef"))
+	assert.Equal(t, Digest{0xde, 0xad, 0xbe, 0xef}, digest)
+	assert.Equal(t, "deadbeef", must(sv.ToString()))
+	buf, err := sv.(StringAppender).AppendString(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "deadbeef", string(buf))
+
+	assert.ErrorIs(t, sv.FromString("deadbe"), ErrLengthMismatch)
+	assert.ErrorIs(t, sv.FromString("deadbeef00"), ErrLengthMismatch)
+	var ce *ConvError
+	assert.ErrorAs(t, sv.FromString("xx"), &ce)
+	assert.Equal(t, typeOf[Digest](), ce.Type)
+	assert.Equal(t, Digest{0xde, 0xad, 0xbe, 0xef}, digest)
+
+	// Byte arrays are converted element by element without BytesEncoding.
+	sv, err = NewNamespace().New(&digest)
+	assert.NoError(t, err)
+	assert.Equal(t, "222,173,190,239", must(sv.ToString()))
+}
+
+func TestBytesEncoding_NamedByteSlice(t *testing.T) {
+	type Hash []byte
+	h, err := Parse[Hash]("CAFE", BytesEncoding(HexEncoding))
+	assert.NoError(t, err)
+	assert.Equal(t, Hash{0xca, 0xfe}, h)
+
+	s, err := Format(h, BytesEncoding(base64.RawStdEncoding))
+	assert.NoError(t, err)
+	assert.Equal(t, "yv4", s)
+}
@@ -0,0 +1,212 @@
+package strconvx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
+// EnumType describes an enum type registered by RegisterEnum. It can be used
+// to parse and format the values directly, and to list the allowed names,
+// e.g. in the help message of a command-line flag.
+type EnumType[T comparable] struct {
+	values     []T
+	names      map[T][]string // the canonical name goes first
+	lookup     map[string]T
+	ignoreCase bool
+}
+
+// EnumOption adjusts the behaviour of an enum type registered by RegisterEnum.
+type EnumOption func(o *enumOptions)
+
+type enumOptions struct {
+	ignoreCase bool
+}
+
+// EnumIgnoreCase makes the enum type match the names case-insensitively,
+// e.g. "Active" and "ACTIVE" are both accepted for "active", in the same way
+// as strings.EqualFold and BoolWords. The values are always formatted with
+// the canonical names.
+func EnumIgnoreCase() EnumOption {
+	return func(o *enumOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// RegisterEnum registers an adaptor for T in the given namespace, which
+// converts the values of T to/from the given names. The first name of each
+// value is the canonical one, which is used by ToString, and the others are
+// the aliases accepted by FromString as well. FromString returns an error
+// wrapping ErrUnknownEnumName, which lists the valid choices, on the names
+// not registered.
+//
+// It panics if ns is nil or the default namespace (see Default), a value has
+// no names, or a name is used by more than one value. The default namespace
+// isn't accepted, since registering an enum there would change the
+// conversions for every user of this package.
+//
+// Example:
+//
+//	type Status int
+//
+//	const (
+//		Active Status = iota
+//		Inactive
+//	)
+//
+//	statuses := strconvx.RegisterEnum(ns, map[Status][]string{
+//		Active:   {"active", "on"},
+//		Inactive: {"inactive", "off"},
+//	}, strconvx.EnumIgnoreCase())
+//	statuses.Names() // ["active", "inactive"]
+func RegisterEnum[T comparable](ns *Namespace, names map[T][]string, opts ...EnumOption) *EnumType[T] {
+	if ns == nil || ns == defaultNS {
+		panic(fmt.Errorf("strconvx: RegisterEnum of %v requires a namespace other than the default one", typeOf[T]()))
+	}
+	o := &enumOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	e := &EnumType[T]{
+		values:     make([]T, 0, len(names)),
+		names:      make(map[T][]string, len(names)),
+		lookup:     make(map[string]T),
+		ignoreCase: o.ignoreCase,
+	}
+	for value, valueNames := range names {
+		if len(valueNames) == 0 {
+			panic(fmt.Errorf("strconvx: enum value %v of %v has no names", value, typeOf[T]()))
+		}
+		for _, name := range valueNames {
+			if other, ok := e.find(name); ok {
+				panic(fmt.Errorf("strconvx: enum name %q of %v is used by both %v and %v", name, typeOf[T](), other, value))
+			}
+			e.lookup[name] = value
+		}
+		e.values = append(e.values, value)
+		e.names[value] = append([]string(nil), valueNames...)
+	}
+	sortEnumValues(e.values, e.names)
+
+	ns.Adapt(ToAnyAdaptor(func(v *T) (StringCodec, error) {
+		return &enumValue[T]{enum: e, value: v}, nil
+	}))
+	return e
+}
+
+// Values returns the registered values, ordered by the values if T is a
+// number or a string, otherwise by the canonical names.
+func (e *EnumType[T]) Values() []T {
+	return append([]T(nil), e.values...)
+}
+
+// Names returns the canonical names of the values, in the same order as Values.
+func (e *EnumType[T]) Names() []string {
+	names := make([]string, len(e.values))
+	for i, value := range e.values {
+		names[i] = e.names[value][0]
+	}
+	return names
+}
+
+// Aliases returns the names of the given value other than the canonical one.
+func (e *EnumType[T]) Aliases(value T) []string {
+	if names, ok := e.names[value]; ok {
+		return append([]string(nil), names[1:]...)
+	}
+	return nil
+}
+
+// Name returns the canonical name of the given value. The second return
+// value reports whether the value is registered.
+func (e *EnumType[T]) Name(value T) (string, bool) {
+	if names, ok := e.names[value]; ok {
+		return names[0], true
+	}
+	return "", false
+}
+
+// Parse returns the value of the given name or alias. It returns an error
+// wrapping ErrUnknownEnumName, which lists the valid choices, if there's no
+// such name.
+func (e *EnumType[T]) Parse(name string) (T, error) {
+	if value, ok := e.find(name); ok {
+		return value, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("%w, valid choices: %s", ErrUnknownEnumName, strings.Join(e.Names(), ", "))
+}
+
+// find returns the value of the given name, which is matched with
+// strings.EqualFold if ignoreCase is set.
+func (e *EnumType[T]) find(name string) (T, bool) {
+	if value, ok := e.lookup[name]; ok || !e.ignoreCase {
+		return value, ok
+	}
+	for n, value := range e.lookup {
+		if strings.EqualFold(n, name) {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// enumValue is the StringCodec of the values of an enum type.
+type enumValue[T comparable] struct {
+	enum  *EnumType[T]
+	value *T
+}
+
+func (ev *enumValue[T]) ToString() (string, error) {
+	name, ok := ev.enum.Name(*ev.value)
+	if !ok {
+		return "", internal.ToStringError(typeOf[T](), fmt.Errorf("%w: %v", ErrUnknownEnumValue, *ev.value))
+	}
+	return name, nil
+}
+
+func (ev *enumValue[T]) AppendString(dst []byte) ([]byte, error) {
+	name, err := ev.ToString()
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, name...), nil
+}
+
+func (ev *enumValue[T]) FromString(s string) error {
+	value, err := ev.enum.Parse(s)
+	if err != nil {
+		return internal.FromStringError(typeOf[T](), s, err)
+	}
+	*ev.value = value
+	return nil
+}
+
+// sortEnumValues sorts the values by themselves if they are numbers or
+// strings, otherwise by their canonical names.
+func sortEnumValues[T comparable](values []T, names map[T][]string) {
+	var less func(a, b reflect.Value) bool
+	switch typeOf[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	default:
+		sort.Slice(values, func(i, j int) bool {
+			return names[values[i]][0] < names[values[j]][0]
+		})
+		return
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return less(reflect.ValueOf(values[i]), reflect.ValueOf(values[j]))
+	})
+}
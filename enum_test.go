@@ -0,0 +1,186 @@
+package strconvx
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusInactive
+	StatusDeleted
+)
+
+func registerStatus(ns *Namespace, opts ...EnumOption) *EnumType[Status] {
+	return RegisterEnum(ns, map[Status][]string{
+		StatusDeleted:  {"deleted"},
+		StatusActive:   {"active", "on", "enabled"},
+		StatusInactive: {"inactive", "off"},
+	}, opts...)
+}
+
+func TestRegisterEnum(t *testing.T) {
+	ns := NewNamespace()
+	registerStatus(ns)
+
+	var s Status
+	sv, err := ns.New(&s)
+	assert.NoError(t, err)
+	for input, expected := range map[string]Status{
+		"active":   StatusActive,
+		"enabled":  StatusActive,
+		"off":      StatusInactive,
+		"deleted":  StatusDeleted,
+		"inactive": StatusInactive,
+	} {
+		assert.NoError(t, sv.FromString(input))
+		assert.Equal(t, expected, s)
+	}
+
+	// The canonical names are used to format the values.
+	s = StatusActive
+	assert.Equal(t, "active", must(sv.ToString()))
+	buf, err := sv.(StringAppender).AppendString([]byte("status="))
+	assert.NoError(t, err)
+	assert.Equal(t, "status=active", string(buf))
+
+	// Slices of the enum type.
+	statuses, err := ParseIn[[]Status](ns, "on,off,deleted")
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{StatusActive, StatusInactive, StatusDeleted}, statuses)
+}
+
+func TestRegisterEnum_Errors(t *testing.T) {
+	ns := NewNamespace()
+	registerStatus(ns)
+
+	s := StatusActive
+	sv, err := ns.New(&s)
+	assert.NoError(t, err)
+
+	err = sv.FromString("Active")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.ErrorIs(t, err, ErrUnknownEnumName)
+	assert.Equal(t, typeOf[Status](), ce.Type)
+	assert.Contains(t, err.Error(), "valid choices: active, inactive, deleted")
+	assert.Equal(t, StatusActive, s)
+
+	s = Status(42)
+	_, err = sv.ToString()
+	assert.ErrorIs(t, err, ErrUnknownEnumValue)
+	assert.ErrorContains(t, err, "42")
+}
+
+func TestRegisterEnum_IgnoreCase(t *testing.T) {
+	ns := NewNamespace()
+	registerStatus(ns, EnumIgnoreCase())
+
+	s, err := ParseIn[Status](ns, "OFF")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusInactive, s)
+	s, err = ParseIn[Status](ns, "Active")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusActive, s)
+	assert.Equal(t, "active", must(FormatIn(ns, s)))
+
+	// The names are matched like the bool words, with strings.EqualFold.
+	ns = NewNamespace()
+	ns.SetOptions(BoolWords(BoolWordSet{True: "\u212a", False: "no"}))
+	type Unit int
+	RegisterEnum(ns, map[Unit][]string{1: {"\u212a"}}, EnumIgnoreCase()) // Kelvin sign
+	for _, input := range []string{"k", "K", "\u212a"} {
+		u, err := ParseIn[Unit](ns, input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, Unit(1), u)
+		b, err := ParseIn[bool](ns, input)
+		assert.NoError(t, err, input)
+		assert.True(t, b)
+	}
+}
+
+func TestRegisterEnum_Introspection(t *testing.T) {
+	statuses := registerStatus(NewNamespace())
+	assert.Equal(t, []Status{StatusActive, StatusInactive, StatusDeleted}, statuses.Values())
+	assert.Equal(t, []string{"active", "inactive", "deleted"}, statuses.Names())
+	assert.Equal(t, []string{"on", "enabled"}, statuses.Aliases(StatusActive))
+	assert.Empty(t, statuses.Aliases(StatusDeleted))
+	assert.Nil(t, statuses.Aliases(Status(42)))
+
+	name, ok := statuses.Name(StatusInactive)
+	assert.True(t, ok)
+	assert.Equal(t, "inactive", name)
+	_, ok = statuses.Name(Status(42))
+	assert.False(t, ok)
+
+	s, err := statuses.Parse("enabled")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusActive, s)
+	_, err = statuses.Parse("unknown")
+	assert.ErrorIs(t, err, ErrUnknownEnumName)
+
+	// The returned slices are copies.
+	statuses.Names()[0] = "changed"
+	statuses.Values()[0] = StatusDeleted
+	assert.Equal(t, []string{"active", "inactive", "deleted"}, statuses.Names())
+}
+
+func TestRegisterEnum_SortedByNames(t *testing.T) {
+	type Point struct{ X, Y int }
+	points := RegisterEnum(NewNamespace(), map[Point][]string{
+		{0, 0}: {"origin"},
+		{1, 0}: {"east"},
+		{0, 1}: {"north"},
+	})
+	assert.Equal(t, []string{"east", "north", "origin"}, points.Names())
+}
+
+func TestRegisterEnum_Panics(t *testing.T) {
+	assert.PanicsWithError(t, `strconvx: enum value 1 of strconvx.Status has no names`, func() {
+		RegisterEnum(NewNamespace(), map[Status][]string{StatusActive: {}})
+	})
+	assert.Panics(t, func() {
+		RegisterEnum(NewNamespace(), map[Status][]string{
+			StatusActive:   {"active", "on"},
+			StatusInactive: {"inactive", "on"},
+		})
+	})
+	// The names conflict when they are matched case-insensitively.
+	assert.Panics(t, func() {
+		RegisterEnum(NewNamespace(), map[Status][]string{
+			StatusActive:   {"active"},
+			StatusInactive: {"ACTIVE"},
+		}, EnumIgnoreCase())
+	})
+}
+
+func TestRegisterEnum_NilNamespace(t *testing.T) {
+	type Level string
+	msg := "strconvx: RegisterEnum of strconvx.Level requires a namespace other than the default one"
+	assert.PanicsWithError(t, msg, func() {
+		RegisterEnum(nil, map[Level][]string{"debug": {"debug", "d"}})
+	})
+	assert.PanicsWithError(t, msg, func() {
+		RegisterEnum(Default(), map[Level][]string{"debug": {"debug", "d"}})
+	})
+}
+
+func TestRegisterEnum_Flag(t *testing.T) {
+	ns := NewNamespace()
+	statuses := registerStatus(ns)
+
+	var s Status
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	codec, err := ns.New(&s)
+	assert.NoError(t, err)
+	fs.Var(FlagValue(codec), "status", "one of "+strings.Join(statuses.Names(), ", "))
+
+	assert.NoError(t, fs.Parse([]string{"-status", "off"}))
+	assert.Equal(t, StatusInactive, s)
+	assert.Equal(t, "one of active, inactive, deleted", fs.Lookup("status").Usage)
+}
@@ -0,0 +1,149 @@
+// Package env populates structs from environment variables with strconvx.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/ggicci/strconvx"
+)
+
+// ErrRequired is reported when a required environment variable is not set.
+var ErrRequired = errors.New("required environment variable not set")
+
+// Option configures Load.
+type Option func(*loader)
+
+// FromMap makes Load read the variables from the given map instead of the
+// environment of the process, which is useful in tests.
+func FromMap(m map[string]string) Option {
+	return func(l *loader) {
+		l.lookup = func(key string) (string, bool) {
+			v, ok := m[key]
+			return v, ok
+		}
+	}
+}
+
+// Prefix prepends the given prefix to the names of all the variables, e.g.
+// with Prefix("APP_"), the field tagged `env:"DB_PORT"` reads APP_DB_PORT.
+func Prefix(prefix string) Option {
+	return func(l *loader) {
+		l.prefix = prefix
+	}
+}
+
+type loader struct {
+	ns     *strconvx.Namespace
+	lookup func(string) (string, bool)
+	prefix string
+	errs   strconvx.FieldErrors
+}
+
+// Load populates the struct dst points to with the environment variables.
+// Each variable is converted to the field with the StringCodec created by the
+// given namespace, see strconvx.Namespace.New. A nil namespace means the
+// default namespace, see strconvx.Default.
+//
+// The fields are configured by struct tags:
+//
+//   - `env:"DB_PORT"`: the name of the variable. The fields without this tag
+//     are skipped, except for the struct fields, see envPrefix.
+//   - `default:"5432"`: the value used when the variable is not set.
+//   - `required:"true"`: report ErrRequired when the variable is not set and
+//     there's no default value.
+//   - `envPrefix:"DB_"`: for a struct field without the env tag, its fields
+//     are loaded with the given prefix prepended to their variable names. The
+//     embedded structs are loaded without a prefix.
+//
+// All the failed fields are reported at once in a strconvx.FieldErrors.
+//
+// Example:
+//
+//	type DBConfig struct {
+//		Host string `env:"HOST" default:"localhost"`
+//		Port int    `env:"PORT" default:"5432"`
+//	}
+//
+//	type Config struct {
+//		DB       DBConfig      `envPrefix:"DB_"`
+//		Timeout  time.Duration `env:"TIMEOUT" default:"30s"`
+//		Password string        `env:"PASSWORD" required:"true"`
+//	}
+//
+//	var cfg Config
+//	err := env.Load(&cfg, ns) // reads DB_HOST, DB_PORT, TIMEOUT and PASSWORD
+func Load(dst any, ns *strconvx.Namespace, opts ...Option) error {
+	rv := reflect.ValueOf(dst)
+	switch {
+	case rv.Kind() != reflect.Pointer:
+		return fmt.Errorf("%w: value must be a non-nil pointer to a struct", strconvx.ErrNotPointer)
+	case rv.IsNil():
+		return fmt.Errorf("%w: value must be a non-nil pointer to a struct", strconvx.ErrNilPointer)
+	case rv.Elem().Kind() != reflect.Struct:
+		return fmt.Errorf("%w: %v", strconvx.ErrUnsupportedType, rv.Type().Elem())
+	}
+	if ns == nil {
+		ns = strconvx.Default()
+	}
+
+	l := &loader{ns: ns, lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.loadStruct(rv.Elem(), l.prefix, "")
+	if len(l.errs) > 0 {
+		return l.errs
+	}
+	return nil
+}
+
+func (l *loader) loadStruct(rv reflect.Value, prefix, path string) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fieldPath := path + field.Name
+		name, tagged := field.Tag.Lookup("env")
+
+		if !tagged {
+			if field.Type.Kind() != reflect.Struct {
+				continue
+			}
+			if field.Anonymous {
+				l.loadStruct(rv.Field(i), prefix, path)
+			} else if field.IsExported() {
+				l.loadStruct(rv.Field(i), prefix+field.Tag.Get("envPrefix"), fieldPath+".")
+			}
+			continue
+		}
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		if err := l.loadField(rv.Field(i), field, key); err != nil {
+			l.errs = append(l.errs, &strconvx.FieldError{Field: fieldPath, Key: key, Err: err})
+		}
+	}
+}
+
+func (l *loader) loadField(rv reflect.Value, field reflect.StructField, key string) error {
+	value, ok := l.lookup(key)
+	if !ok {
+		value, ok = field.Tag.Lookup("default")
+	}
+	if !ok {
+		if field.Tag.Get("required") == "true" {
+			return ErrRequired
+		}
+		return nil
+	}
+
+	codec, err := l.ns.New(rv.Addr().Interface())
+	if err != nil {
+		return err
+	}
+	return codec.FromString(value)
+}
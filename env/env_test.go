@@ -0,0 +1,143 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ggicci/strconvx"
+	"github.com/stretchr/testify/assert"
+)
+
+type DBConfig struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type Logging struct {
+	Level string `env:"LOG_LEVEL" default:"info"`
+}
+
+type Config struct {
+	Logging
+	DB       DBConfig      `envPrefix:"DB_"`
+	Replica  DBConfig      `envPrefix:"REPLICA_"`
+	Timeout  time.Duration `env:"TIMEOUT" default:"30s"`
+	Password string        `env:"PASSWORD" required:"true"`
+	Tags     []string      `env:"TAGS"`
+	Debug    *bool         `env:"DEBUG"`
+	Ignored  string
+	skipped  string `env:"SKIPPED"`
+}
+
+func TestLoad(t *testing.T) {
+	var cfg Config
+	err := Load(&cfg, nil, FromMap(map[string]string{
+		"DB_HOST":      "db.example.com",
+		"REPLICA_PORT": "5433",
+		"PASSWORD":     "secret",
+		"TAGS":         "a,b",
+		"DEBUG":        "true",
+		"LOG_LEVEL":    "debug",
+		"Ignored":      "x",
+		"SKIPPED":      "x",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Level)
+	assert.Equal(t, DBConfig{Host: "db.example.com", Port: 5432}, cfg.DB)
+	assert.Equal(t, DBConfig{Host: "localhost", Port: 5433}, cfg.Replica)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, "secret", cfg.Password)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.True(t, *cfg.Debug)
+	assert.Empty(t, cfg.Ignored)
+	assert.Empty(t, cfg.skipped)
+}
+
+func TestLoad_Prefix(t *testing.T) {
+	var cfg Config
+	err := Load(&cfg, nil, Prefix("APP_"), FromMap(map[string]string{
+		"APP_DB_PORT":  "6432",
+		"APP_PASSWORD": "secret",
+		"PASSWORD":     "wrong",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, 6432, cfg.DB.Port)
+	assert.Equal(t, "secret", cfg.Password)
+}
+
+func TestLoad_OS(t *testing.T) {
+	t.Setenv("STRCONVX_TEST_PASSWORD", "from-os")
+
+	var cfg Config
+	assert.NoError(t, Load(&cfg, nil, Prefix("STRCONVX_TEST_")))
+	assert.Equal(t, "from-os", cfg.Password)
+	_, ok := os.LookupEnv("STRCONVX_TEST_DB_PORT")
+	assert.False(t, ok)
+	assert.Equal(t, 5432, cfg.DB.Port)
+}
+
+func TestLoad_Namespace(t *testing.T) {
+	ns := strconvx.NewNamespace()
+	typ, adaptor := strconvx.ToAnyAdaptor(func(b *bool) (strconvx.StringCodec, error) {
+		return (*onOff)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	var cfg Config
+	err := Load(&cfg, ns, FromMap(map[string]string{"PASSWORD": "secret", "DEBUG": "on"}))
+	assert.NoError(t, err)
+	assert.True(t, *cfg.Debug)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	var cfg Config
+	err := Load(&cfg, nil, FromMap(map[string]string{
+		"DB_PORT": "hello",
+		"TIMEOUT": "forever",
+	}))
+
+	var fieldErrors strconvx.FieldErrors
+	assert.ErrorAs(t, err, &fieldErrors)
+	assert.Len(t, fieldErrors, 3)
+	assert.Equal(t, "DB.Port", fieldErrors[0].Field)
+	assert.Equal(t, "DB_PORT", fieldErrors[0].Key)
+	assert.ErrorIs(t, fieldErrors[0], strconv.ErrSyntax)
+	assert.Equal(t, "Timeout", fieldErrors[1].Field)
+	assert.Equal(t, "Password", fieldErrors[2].Field)
+	assert.ErrorIs(t, fieldErrors[2], ErrRequired)
+	assert.ErrorContains(t, err, "field Password (PASSWORD): required environment variable not set")
+}
+
+func TestLoad_InvalidDestination(t *testing.T) {
+	var cfg Config
+	assert.ErrorIs(t, Load(cfg, nil), strconvx.ErrNotPointer)
+	assert.ErrorIs(t, Load(nil, nil), strconvx.ErrNotPointer)
+	assert.ErrorIs(t, Load((*Config)(nil), nil), strconvx.ErrNilPointer)
+	var i int
+	assert.ErrorIs(t, Load(&i, nil), strconvx.ErrUnsupportedType)
+}
+
+type onOff bool
+
+func (b onOff) ToString() (string, error) {
+	if b {
+		return "on", nil
+	}
+	return "off", nil
+}
+
+func (b *onOff) FromString(s string) error {
+	switch strings.ToLower(s) {
+	case "on":
+		*b = true
+	case "off":
+		*b = false
+	default:
+		return errors.New("invalid value")
+	}
+	return nil
+}
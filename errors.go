@@ -1,6 +1,12 @@
 package strconvx
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ggicci/strconvx/internal"
+)
 
 var (
 	ErrUnsupportedType      = errors.New("unsupported type")
@@ -9,4 +15,59 @@ var (
 	ErrNotStringUnmarshaler = errors.New("not a StringUnmarshaler")
 	ErrNotPointer           = errors.New("not a pointer")
 	ErrNilPointer           = errors.New("nil pointer")
+	ErrLengthMismatch       = errors.New("length mismatch")
+	ErrNonFinite            = internal.ErrNonFinite
+	ErrUnknownEnumName      = errors.New("unknown enum name")
+	ErrUnknownEnumValue     = errors.New("unknown enum value")
 )
+
+// ConvError records a failed conversion between a value and a string. It's
+// returned by the StringCodecs of the builtin types, slices, arrays, maps and
+// the hybrid instances. Use errors.As to retrieve it:
+//
+//	var ce *strconvx.ConvError
+//	if errors.As(err, &ce) {
+//		ce.Op    // "FromString" or "ToString"
+//		ce.Type  // the type of the value being converted, e.g. int
+//		ce.Input // the input of FromString, truncated to 64 bytes
+//		ce.Err   // the cause, e.g. a *strconv.NumError
+//		ce.Redact() // remove the input from the error message
+//	}
+//
+// It wraps the cause, so errors.Is works with the cause as well, e.g.
+// errors.Is(err, strconv.ErrRange), errors.Is(err, ErrNotStringUnmarshaler).
+type ConvError = internal.ConvError
+
+// FieldError records a failed conversion of a struct field.
+type FieldError struct {
+	Field string // the name of the struct field, e.g. "Page"
+	Key   string // the key of the field in the source, e.g. "page"
+	Err   error  // the cause
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (%s): %v", e.Field, e.Key, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors reports all the failed fields of a struct at once.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
@@ -0,0 +1,167 @@
+package strconvx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ggicci/strconvx/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvError_Builtin(t *testing.T) {
+	var i int
+	sv, err := New(&i)
+	assert.NoError(t, err)
+
+	err = sv.FromString("hello")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, "FromString", ce.Op)
+	assert.Equal(t, typeOf[int](), ce.Type)
+	assert.Equal(t, "hello", ce.Input)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.EqualError(t, err, `cannot convert "hello" to int: invalid syntax`)
+
+	// The cause of the builtin numeric types is the *strconv.NumError.
+	var ne *strconv.NumError
+	assert.ErrorAs(t, err, &ne)
+	assert.Equal(t, "hello", ne.Num)
+	_, err = Parse[int]("abc")
+	assert.ErrorAs(t, err, &ne)
+
+	var u8 uint8
+	sv, err = New(&u8)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, sv.FromString("256"), strconv.ErrRange)
+
+	var tm time.Time
+	sv, err = New(&tm)
+	assert.NoError(t, err)
+	assert.EqualError(t, sv.FromString("hello"), `cannot convert "hello" to time.Time: invalid time value`)
+}
+
+func TestConvError_NamedType(t *testing.T) {
+	type UserID int64
+	var id UserID
+	sv, err := New(&id)
+	assert.NoError(t, err)
+
+	err = sv.FromString("hello")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[UserID](), ce.Type)
+}
+
+func TestConvError_Hybrid(t *testing.T) {
+	apple := &TextMarshalerApple{}
+	sv, err := New(apple)
+	assert.NoError(t, err)
+
+	err = sv.FromString("red apple")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[TextMarshalerApple](), ce.Type)
+	assert.ErrorIs(t, err, ErrNotStringUnmarshaler)
+
+	watermelon := &TextMarshalerSpoiledWatermelon{}
+	sv, err = New(watermelon)
+	assert.NoError(t, err)
+	_, err = sv.ToString()
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, "ToString", ce.Op)
+	assert.EqualError(t, err, "cannot convert strconvx.TextMarshalerSpoiledWatermelon to string: spoiled")
+}
+
+func TestConvError_Slice(t *testing.T) {
+	var ids []int
+	sv, err := New(&ids)
+	assert.NoError(t, err)
+
+	err = sv.FromString("1,x")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[[]int](), ce.Type)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.EqualError(t, err, `cannot convert "1,x" to []int: element 1: cannot convert "x" to int: invalid syntax`)
+}
+
+func TestConvError_TruncateAndRedact(t *testing.T) {
+	var i int
+	sv, err := New(&i)
+	assert.NoError(t, err)
+
+	err = sv.FromString(strings.Repeat("x", 100))
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, strings.Repeat("x", 64)+"...", ce.Input)
+
+	err = sv.FromString(strings.Repeat("你", 30)) // 3 bytes per rune
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, strings.Repeat("你", 21)+"...", ce.Input)
+
+	err = sv.FromString("secret")
+	assert.ErrorAs(t, err, &ce)
+	ce.Redact()
+	assert.Empty(t, ce.Input)
+	assert.EqualError(t, err, "cannot convert [REDACTED] to int: invalid syntax")
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+}
+
+func TestConvError_RedactNested(t *testing.T) {
+	testcases := []struct {
+		name     string
+		parse    func() error
+		expected string
+	}{
+		{"Slice", func() error {
+			_, err := Parse[[]int]("1,secret")
+			return err
+		}, "cannot convert [REDACTED] to []int: element 1: cannot convert [REDACTED] to int: invalid syntax"},
+		{"Map", func() error {
+			_, err := Parse[map[string]int]("a=1,secret=x")
+			return err
+		}, "cannot convert [REDACTED] to map[string]int: value of key [REDACTED]: cannot convert [REDACTED] to int: invalid syntax"},
+		{"Pointer", func() error {
+			_, err := Parse[*int]("secret")
+			return err
+		}, "secret"},
+	}
+
+	for _, c := range testcases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.parse()
+			assert.Contains(t, err.Error(), "secret")
+
+			var ce *ConvError
+			assert.ErrorAs(t, err, &ce)
+			ce.Redact()
+			assert.NotContains(t, err.Error(), "secret")
+			assert.Contains(t, err.Error(), "[REDACTED]")
+			if c.expected != "secret" {
+				assert.EqualError(t, err, c.expected)
+			}
+
+			// The inputs kept in the wrapped errors are removed as well.
+			assert.NotContains(t, fmt.Sprintf("%+v", err), "secret")
+			var ne *strconv.NumError
+			assert.ErrorAs(t, err, &ne)
+			assert.Empty(t, ne.Num)
+			assert.ErrorIs(t, err, strconv.ErrSyntax)
+		})
+	}
+}
+
+func TestConvError_RedactMapKey(t *testing.T) {
+	_, err := Parse[map[string]string]("a=1,secret")
+	assert.EqualError(t, err, `cannot convert "a=1,secret" to map[string]string: key "secret": missing key-value separator`)
+
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	ce.Redact()
+	assert.EqualError(t, err, "cannot convert [REDACTED] to map[string]string: key [REDACTED]: missing key-value separator")
+	assert.ErrorIs(t, err, internal.ErrMissingKeyValueSeparator)
+}
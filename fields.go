@@ -0,0 +1,68 @@
+package strconvx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structField is an exported field of a struct value, see structFields.
+type structField struct {
+	reflect.StructField
+	Value reflect.Value
+}
+
+// structFields returns the exported fields of the given struct value. The
+// fields of the embedded structs without a tag of the given name are
+// flattened into the result.
+func structFields(rv reflect.Value, tagName string) []structField {
+	var fields []structField
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if _, tagged := field.Tag.Lookup(tagName); !tagged {
+				fields = append(fields, structFields(rv.Field(i), tagName)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fields = append(fields, structField{field, rv.Field(i)})
+	}
+	return fields
+}
+
+// parseTag parses a struct tag in the form of "name,opt1,opt2". The name is
+// the field name if it's absent in the tag. Returns an empty name if the
+// field should be skipped, i.e. the tag is "-".
+func parseTag(field reflect.StructField, tagName string) (name string, opts []string) {
+	name, rest, hasOpts := strings.Cut(field.Tag.Get(tagName), ",")
+	if name == "-" && !hasOpts {
+		return "", nil
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if rest != "" {
+		opts = strings.Split(rest, ",")
+	}
+	return name, opts
+}
+
+// structValue returns the struct value v points to. It's for the functions
+// taking a pointer to a struct, e.g. DecodeValues.
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return rv, fmt.Errorf("%w: value must be a non-nil pointer to a struct", ErrNotPointer)
+	}
+	if rv.IsNil() {
+		return rv, fmt.Errorf("%w: value must be a non-nil pointer to a struct", ErrNilPointer)
+	}
+	if rv.Elem().Kind() != reflect.Struct {
+		return rv, unsupportedType(rv.Type().Elem())
+	}
+	return rv.Elem(), nil
+}
@@ -0,0 +1,50 @@
+package strconvx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructFields(t *testing.T) {
+	var q ListUsersQuery
+	var names []string
+	for _, field := range structFields(reflect.ValueOf(&q).Elem(), valuesTag) {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{
+		"Page", "PerPage", "Name", "Roles", "IDs", "Tags", "Active",
+		"Timeout", "Answer", "Token", "Internal", "NoTag",
+	}, names)
+}
+
+func TestParseTag(t *testing.T) {
+	var s struct {
+		A int `strconvx:"a,omitempty,x"`
+		B int `strconvx:",omitempty"`
+		C int `strconvx:"-"`
+		D int `strconvx:"-,"`
+		E int
+	}
+	rt := reflect.TypeOf(s)
+
+	name, opts := parseTag(rt.Field(0), valuesTag)
+	assert.Equal(t, "a", name)
+	assert.Equal(t, []string{"omitempty", "x"}, opts)
+
+	name, opts = parseTag(rt.Field(1), valuesTag)
+	assert.Equal(t, "B", name)
+	assert.Equal(t, []string{"omitempty"}, opts)
+
+	name, _ = parseTag(rt.Field(2), valuesTag)
+	assert.Empty(t, name)
+
+	name, opts = parseTag(rt.Field(3), valuesTag)
+	assert.Equal(t, "-", name)
+	assert.Nil(t, opts)
+
+	name, opts = parseTag(rt.Field(4), valuesTag)
+	assert.Equal(t, "E", name)
+	assert.Nil(t, opts)
+}
@@ -0,0 +1,146 @@
+package strconvx
+
+import (
+	"flag"
+	"reflect"
+)
+
+// flagTag is the struct tag used by BindFlags to specify the flag names.
+const flagTag = "flag"
+
+// FlagValue adapts a StringCodec to a flag.Value, so that it can be used with
+// flag.Var. If the StringCodec converts bool values with the builtin codec,
+// directly or through pointers, the flag.Value is a boolean flag, i.e.
+// "-name" is equivalent to "-name=true".
+//
+// Example:
+//
+//	var timeout time.Duration
+//	codec, _ := strconvx.New(&timeout)
+//	flag.Var(strconvx.FlagValue(codec), "timeout", "request timeout")
+func FlagValue(codec StringCodec) flag.Value {
+	return &flagValue{StringCodec: codec, boolFlag: isBoolFlag(codec)}
+}
+
+type flagValue struct {
+	StringCodec
+	boolFlag bool
+
+	// trueWord replaces "true", which the flag package sets for "-name" of a
+	// boolean flag, for the bools converted by an adaptor, e.g. "yes".
+	trueWord string
+}
+
+func (f *flagValue) String() string {
+	// The flag package calls String on a zero value to tell whether the
+	// default value is a zero value.
+	if f.StringCodec == nil {
+		return ""
+	}
+	s, _ := f.ToString()
+	return s
+}
+
+func (f *flagValue) Set(s string) error {
+	if s == "true" && f.trueWord != "" {
+		s = f.trueWord
+	}
+	return f.FromString(s)
+}
+
+// IsBoolFlag implements the unexported boolFlag interface of the flag package.
+func (f *flagValue) IsBoolFlag() bool {
+	return f.boolFlag
+}
+
+// isBoolFlag reports whether the codec implements IsBoolFlag and returns true.
+func isBoolFlag(codec StringCodec) bool {
+	if nc, ok := codec.(*namedCodec); ok {
+		codec = nc.StringCodec
+	}
+	bf, ok := codec.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+// trueWordOf returns the string true is formatted to by the namespace, e.g.
+// "yes" with an adaptor for bool. The second return value reports whether
+// true can be formatted.
+func trueWordOf(ns *Namespace) (string, bool) {
+	v := true
+	codec, err := ns.New(&v)
+	if err != nil {
+		return "", false
+	}
+	s, err := codec.ToString()
+	return s, err == nil
+}
+
+// isBoolType reports whether typ is bool or a pointer to bool, e.g. *bool,
+// **bool.
+func isBoolType(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ == typeOf[bool]()
+}
+
+// BindFlags registers a flag for each field of the struct cfg points to,
+// which has a "flag" tag, e.g. `flag:"timeout"`. The usage of the flag is
+// specified by the "usage" tag. The flag values are converted with the
+// StringCodecs created by the given namespace, nil means the default
+// namespace. The current values of the fields are the default values of the
+// flags, which are rendered by ToString in the help message, except for the
+// zero values, like the flags of the flag package. The fields of bool and
+// *bool are boolean flags, i.e. "-name" is equivalent to "-name=true". If the
+// namespace converts bool with an adaptor, "-name" sets the string the
+// adaptor formats true to instead, e.g. "-name=yes".
+//
+// All the fields that cannot be bound are reported at once in a FieldErrors.
+//
+// Example:
+//
+//	type Options struct {
+//		Addr    string        `flag:"addr" usage:"listen address"`
+//		Timeout time.Duration `flag:"timeout" usage:"request timeout"`
+//		Verbose bool          `flag:"v" usage:"verbose output"`
+//	}
+//
+//	opts := Options{Addr: ":8080", Timeout: 30 * time.Second}
+//	err := strconvx.BindFlags(flag.CommandLine, &opts, nil)
+//	flag.Parse()
+func BindFlags(fs *flag.FlagSet, cfg any, ns *Namespace) error {
+	rv, err := structValue(cfg)
+	if err != nil {
+		return err
+	}
+	if ns == nil {
+		ns = defaultNS
+	}
+
+	var errs FieldErrors
+	for _, field := range structFields(rv, flagTag) {
+		name, ok := field.Tag.Lookup(flagTag)
+		if !ok || name == "" || name == "-" {
+			continue
+		}
+		codec, err := ns.New(field.Value.Addr().Interface())
+		if err != nil {
+			errs = append(errs, &FieldError{Field: field.Name, Key: name, Err: err})
+			continue
+		}
+		fv := &flagValue{StringCodec: codec, boolFlag: isBoolFlag(codec)}
+		if !fv.boolFlag && isBoolType(field.Type) {
+			fv.trueWord, fv.boolFlag = trueWordOf(ns)
+		}
+		fs.Var(fv, name, field.Tag.Get("usage"))
+		if field.Value.IsZero() {
+			// The flag package tells the zero default values by the String of
+			// a zero flagValue, which doesn't know the type of the value.
+			fs.Lookup(name).DefValue = ""
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package strconvx
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagValue(t *testing.T) {
+	var timeout time.Duration
+	codec, err := New(&timeout)
+	assert.NoError(t, err)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(FlagValue(codec), "timeout", "request timeout")
+	assert.NoError(t, fs.Parse([]string{"-timeout", "1m30s"}))
+	assert.Equal(t, 90*time.Second, timeout)
+	assert.Equal(t, "1m30s", fs.Lookup("timeout").Value.String())
+
+	fs.SetOutput(&bytes.Buffer{})
+	assert.ErrorContains(t, fs.Parse([]string{"-timeout", "forever"}), `invalid value "forever" for flag -timeout`)
+}
+
+func TestFlagValue_IsBoolFlag(t *testing.T) {
+	type Verbose bool
+	var debug bool
+	var verbose Verbose
+	var count int
+	var quiet *bool
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	for name, v := range map[string]any{"debug": &debug, "v": &verbose, "n": &count, "q": &quiet} {
+		codec, err := New(v)
+		assert.NoError(t, err)
+		fs.Var(FlagValue(codec), name, "")
+	}
+	assert.True(t, fs.Lookup("debug").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+	assert.True(t, fs.Lookup("v").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+	assert.False(t, fs.Lookup("n").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+	assert.True(t, fs.Lookup("q").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+
+	assert.NoError(t, fs.Parse([]string{"-debug", "-v", "-n", "3", "-q"}))
+	assert.True(t, *quiet)
+	assert.True(t, debug)
+	assert.True(t, bool(verbose))
+	assert.Equal(t, 3, count)
+}
+
+type ServerOptions struct {
+	Addr    string        `flag:"addr" usage:"listen address"`
+	Timeout time.Duration `flag:"timeout" usage:"request timeout"`
+	Verbose bool          `flag:"v" usage:"verbose output"`
+	Tags    []string      `flag:"tags" usage:"comma separated tags"`
+	Answer  YesNo         `flag:"answer" usage:"yes or no"`
+	Ignored string
+}
+
+func TestBindFlags(t *testing.T) {
+	opts := ServerOptions{Addr: ":8080", Timeout: 30 * time.Second}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, nil))
+
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	assert.Contains(t, buf.String(), "listen address (default :8080)")
+	assert.Contains(t, buf.String(), "request timeout (default 30s)")
+	assert.Contains(t, buf.String(), "-answer value\n    \tyes or no\n")
+	assert.NotContains(t, buf.String(), "Ignored")
+
+	assert.NoError(t, fs.Parse([]string{"-addr", ":9090", "-v", "-tags", "a,b", "-answer", "yes"}))
+	assert.Equal(t, ServerOptions{
+		Addr:    ":9090",
+		Timeout: 30 * time.Second,
+		Verbose: true,
+		Tags:    []string{"a", "b"},
+		Answer:  true,
+	}, opts)
+}
+
+func TestBindFlags_Namespace(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	var opts ServerOptions
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, ns))
+	assert.NoError(t, fs.Parse([]string{"-v=yes"}))
+	assert.True(t, opts.Verbose)
+}
+
+func TestBindFlags_BoolFields(t *testing.T) {
+	ns := NewNamespace()
+	ns.Adapt(ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	}))
+
+	var opts struct {
+		Debug   *bool `flag:"debug"`
+		Verbose bool  `flag:"v"`
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, nil))
+	assert.NoError(t, fs.Parse([]string{"-debug", "-v"}))
+	assert.True(t, *opts.Debug)
+	assert.True(t, opts.Verbose)
+
+	// The bool fields converted by an adaptor are boolean flags as well,
+	// which set the true word of the adaptor when given bare.
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, ns))
+	assert.True(t, fs.Lookup("v").Value.(interface{ IsBoolFlag() bool }).IsBoolFlag())
+	assert.NoError(t, fs.Parse([]string{"-debug=no", "-v=no"}))
+	assert.False(t, *opts.Debug)
+	assert.False(t, opts.Verbose)
+
+	opts.Debug, opts.Verbose = nil, false
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, ns))
+	assert.NoError(t, fs.Parse([]string{"-debug", "-v"}))
+	assert.True(t, *opts.Debug)
+	assert.True(t, opts.Verbose)
+	assert.Equal(t, "yes", fs.Lookup("v").Value.String())
+}
+
+func TestBindFlags_ZeroDefaults(t *testing.T) {
+	var opts struct {
+		Count   int           `flag:"n" usage:"count"`
+		Verbose bool          `flag:"v" usage:"verbose output"`
+		Timeout time.Duration `flag:"timeout" usage:"request timeout"`
+		Retries int           `flag:"retries" usage:"retries"`
+	}
+	opts.Retries = 3
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(fs, &opts, nil))
+
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	assert.Contains(t, buf.String(), "\tcount\n")
+	assert.Contains(t, buf.String(), "\tverbose output\n")
+	assert.Contains(t, buf.String(), "\trequest timeout\n")
+	assert.Contains(t, buf.String(), "\tretries (default 3)\n")
+}
+
+func TestBindFlags_Errors(t *testing.T) {
+	var opts struct {
+		Object StructNotStringConvertable `flag:"object"`
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	err := BindFlags(fs, &opts, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.ErrorContains(t, err, "field Object (object)")
+
+	assert.ErrorIs(t, BindFlags(fs, opts, nil), ErrNotPointer)
+}
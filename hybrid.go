@@ -3,25 +3,35 @@ package strconvx
 import (
 	"encoding"
 	"reflect"
+
+	"github.com/ggicci/strconvx/internal"
 )
 
 type hybrid struct {
 	StringMarshaler
 	StringUnmarshaler
+	typ reflect.Type
 }
 
 func (h *hybrid) ToString() (string, error) {
-	if h.StringMarshaler != nil {
-		return h.StringMarshaler.ToString()
+	if h.StringMarshaler == nil {
+		return "", internal.ToStringError(h.typ, ErrNotStringMarshaler)
 	}
-	return "", ErrNotStringMarshaler
+	s, err := h.StringMarshaler.ToString()
+	if err != nil {
+		return "", internal.ToStringError(h.typ, err)
+	}
+	return s, nil
 }
 
 func (h *hybrid) FromString(s string) error {
-	if h.StringUnmarshaler != nil {
-		return h.StringUnmarshaler.FromString(s)
+	if h.StringUnmarshaler == nil {
+		return internal.FromStringError(h.typ, s, ErrNotStringUnmarshaler)
 	}
-	return ErrNotStringUnmarshaler
+	if err := h.StringUnmarshaler.FromString(s); err != nil {
+		return internal.FromStringError(h.typ, s, err)
+	}
+	return nil
 }
 
 func (h *hybrid) IsValid() bool {
@@ -44,33 +54,49 @@ func (h *hybrid) validateAsComplete() error {
 // encoding.TextMarshaler, and encoding.TextUnmarshaler. Returns nil if the
 // reflect.Value does not implement any of the above.
 func createHybridStringCodec(rv reflect.Value) StringCodec {
-	h := &hybrid{}
+	if newHybrid := hybridOf(rv.Type()); newHybrid != nil {
+		return newHybrid(rv)
+	}
+	return nil
+}
 
-	// Check strconvx.StringMarshaler and encoding.TextMarshaler.
-	if rv.Type().Implements(stringMarshalerType) {
-		h.StringMarshaler = rv.Interface().(StringMarshaler)
-	} else if rv.Type().Implements(textMarshalerType) {
-		h.StringMarshaler = &textMarshaler{
-			rv.Interface().(encoding.TextMarshaler),
-			nil,
-		}
+// hybridOf checks the interfaces implemented by the given type once, and
+// returns a function that creates hybrid instances from the values of the
+// type, see createHybridStringCodec. Returns nil if the type does not
+// implement any of the interfaces.
+func hybridOf(typ reflect.Type) func(rv reflect.Value) *hybrid {
+	elemType := typ
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
 	}
 
+	// Check strconvx.StringMarshaler and encoding.TextMarshaler.
+	isStringMarshaler := typ.Implements(stringMarshalerType)
+	isTextMarshaler := !isStringMarshaler && typ.Implements(textMarshalerType)
+
 	// Check strconvx.StringUnmarshaler and encoding.TextUnmarshaler.
-	if rv.Type().Implements(stringUnmarshalerType) {
-		h.StringUnmarshaler = rv.Interface().(StringUnmarshaler)
-	} else if rv.Type().Implements(textUnmarshalerType) {
-		h.StringUnmarshaler = &textMarshaler{
-			nil,
-			rv.Interface().(encoding.TextUnmarshaler),
-		}
+	isStringUnmarshaler := typ.Implements(stringUnmarshalerType)
+	isTextUnmarshaler := !isStringUnmarshaler && typ.Implements(textUnmarshalerType)
+
+	if !isStringMarshaler && !isTextMarshaler && !isStringUnmarshaler && !isTextUnmarshaler {
+		return nil
 	}
 
-	if h.IsValid() {
+	return func(rv reflect.Value) *hybrid {
+		h := &hybrid{typ: elemType}
+		v := rv.Interface()
+		if isStringMarshaler {
+			h.StringMarshaler = v.(StringMarshaler)
+		} else if isTextMarshaler {
+			h.StringMarshaler = &textMarshaler{v.(encoding.TextMarshaler), nil}
+		}
+		if isStringUnmarshaler {
+			h.StringUnmarshaler = v.(StringUnmarshaler)
+		} else if isTextUnmarshaler {
+			h.StringUnmarshaler = &textMarshaler{nil, v.(encoding.TextUnmarshaler)}
+		}
 		return h
 	}
-
-	return nil
 }
 
 type textMarshaler struct {
@@ -1,6 +1,9 @@
 package internal
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 type Bool bool
 
@@ -8,11 +11,106 @@ func (b Bool) ToString() (string, error) {
 	return strconv.FormatBool(bool(b)), nil
 }
 
+func (b Bool) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendBool(dst, bool(b)), nil
+}
+
 func (b *Bool) FromString(s string) error {
 	v, err := strconv.ParseBool(s)
 	if err != nil {
-		return err
+		return fromStringError[bool](s, err)
 	}
 	*b = Bool(v)
 	return nil
 }
+
+// IsBoolFlag makes the flag.Value of a Bool a boolean flag, see strconvx.FlagValue.
+func (b Bool) IsBoolFlag() bool {
+	return true
+}
+
+// BoolWords is a pair of the words of true and false, e.g. "yes" and "no".
+type BoolWords struct {
+	True, False string
+}
+
+// BoolWithOptions is similar to Bool, but also accepts the given words, and
+// outputs the given words instead of "true" and "false".
+type BoolWithOptions struct {
+	Value *bool
+
+	// Words are accepted by FromString in addition to the ones accepted by
+	// strconv.ParseBool.
+	Words []BoolWords
+
+	// Output is the pair of words used by ToString, the zero value means
+	// "true" and "false". Output is accepted by FromString as well.
+	Output BoolWords
+
+	// CaseSensitive makes FromString match Words and Output exactly.
+	CaseSensitive bool
+}
+
+func (b BoolWithOptions) ToString() (string, error) {
+	return b.format(), nil
+}
+
+func (b BoolWithOptions) AppendString(dst []byte) ([]byte, error) {
+	return append(dst, b.format()...), nil
+}
+
+func (b BoolWithOptions) FromString(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		var ok bool
+		if v, ok = b.parseWord(s); !ok {
+			return fromStringError[bool](s, err)
+		}
+	}
+	*b.Value = v
+	return nil
+}
+
+// IsBoolFlag makes the flag.Value of a BoolWithOptions a boolean flag, see
+// strconvx.FlagValue.
+func (b BoolWithOptions) IsBoolFlag() bool {
+	return true
+}
+
+func (b BoolWithOptions) format() string {
+	if b.Output == (BoolWords{}) {
+		return strconv.FormatBool(*b.Value)
+	}
+	if *b.Value {
+		return b.Output.True
+	}
+	return b.Output.False
+}
+
+func (b BoolWithOptions) parseWord(s string) (value, ok bool) {
+	if b.Output != (BoolWords{}) {
+		if value, ok = b.Output.match(s, b.CaseSensitive); ok {
+			return value, ok
+		}
+	}
+	for _, words := range b.Words {
+		if value, ok = words.match(s, b.CaseSensitive); ok {
+			return value, ok
+		}
+	}
+	return false, false
+}
+
+func (w BoolWords) match(s string, caseSensitive bool) (value, ok bool) {
+	equal := strings.EqualFold
+	if caseSensitive {
+		equal = func(a, b string) bool { return a == b }
+	}
+	switch {
+	case equal(s, w.True):
+		return true, true
+	case equal(s, w.False):
+		return false, true
+	}
+	return false, false
+}
@@ -5,18 +5,49 @@ import (
 )
 
 // ByteSlice is a wrapper of []byte to implement StringCodec.
-// NOTE: we're using base64.StdEncoding here, not base64.URLEncoding.
+// NOTE: we're using base64.StdEncoding here, not base64.URLEncoding. See
+// ByteSliceWithEncoding for the other encodings.
 type ByteSlice []byte
 
 func (bs ByteSlice) ToString() (string, error) {
 	return base64.StdEncoding.EncodeToString(bs), nil
 }
 
+func (bs ByteSlice) AppendString(dst []byte) ([]byte, error) {
+	return base64.StdEncoding.AppendEncode(dst, bs), nil
+}
+
 func (bs *ByteSlice) FromString(s string) error {
 	v, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
-		return err
+		return fromStringError[[]byte](s, err)
 	}
 	*bs = ByteSlice(v)
 	return nil
 }
+
+// ByteSliceWithEncoding is similar to ByteSlice, but with the given Encoding.
+// When Lenient is true, FromString ignores the whitespaces and the missing
+// padding, see DecodeString.
+type ByteSliceWithEncoding struct {
+	Value    *[]byte
+	Encoding Encoding
+	Lenient  bool
+}
+
+func (bs ByteSliceWithEncoding) ToString() (string, error) {
+	return bs.Encoding.EncodeToString(*bs.Value), nil
+}
+
+func (bs ByteSliceWithEncoding) AppendString(dst []byte) ([]byte, error) {
+	return AppendEncode(bs.Encoding, dst, *bs.Value), nil
+}
+
+func (bs ByteSliceWithEncoding) FromString(s string) error {
+	v, err := DecodeString(bs.Encoding, s, bs.Lenient)
+	if err != nil {
+		return fromStringError[[]byte](s, err)
+	}
+	*bs.Value = v
+	return nil
+}
@@ -1,6 +1,9 @@
 package internal
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+)
 
 type Complex128 complex128
 
@@ -8,11 +11,29 @@ func (c Complex128) ToString() (string, error) {
 	return strconv.FormatComplex(complex128(c), 'f', -1, 128), nil
 }
 
+func (c Complex128) AppendString(dst []byte) ([]byte, error) {
+	return appendComplex(dst, complex128(c), 128), nil
+}
+
 func (c *Complex128) FromString(s string) error {
 	v, err := strconv.ParseComplex(s, 128)
 	if err != nil {
-		return err
+		return fromStringError[complex128](s, err)
 	}
 	*c = Complex128(v)
 	return nil
 }
+
+// appendComplex appends the string form of c to dst, which is the same as
+// strconv.FormatComplex(c, 'f', -1, bitSize).
+func appendComplex(dst []byte, c complex128, bitSize int) []byte {
+	bitSize >>= 1 // complex64 uses float32 internally
+	dst = append(dst, '(')
+	dst = strconv.AppendFloat(dst, real(c), 'f', -1, bitSize)
+	// The imaginary part always has a sign, e.g. "(1+2i)", "(1+NaNi)".
+	if im := imag(c); math.IsNaN(im) || (!math.Signbit(im) && !math.IsInf(im, 1)) {
+		dst = append(dst, '+')
+	}
+	dst = strconv.AppendFloat(dst, imag(c), 'f', -1, bitSize)
+	return append(dst, 'i', ')')
+}
@@ -8,10 +8,14 @@ func (c Complex64) ToString() (string, error) {
 	return strconv.FormatComplex(complex128(c), 'f', -1, 64), nil
 }
 
+func (c Complex64) AppendString(dst []byte) ([]byte, error) {
+	return appendComplex(dst, complex128(c), 64), nil
+}
+
 func (c *Complex64) FromString(s string) error {
 	v, err := strconv.ParseComplex(s, 64)
 	if err != nil {
-		return err
+		return fromStringError[complex64](s, err)
 	}
 	*c = Complex64(v)
 	return nil
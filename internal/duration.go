@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Duration is a wrapper of time.Duration to implement StringCodec.
+// It accepts the format of time.ParseDuration, e.g. "1h30m", "300ms".
+type Duration time.Duration
+
+func (d Duration) ToString() (string, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d Duration) AppendString(dst []byte) ([]byte, error) {
+	return append(dst, time.Duration(d).String()...), nil
+}
+
+func (d *Duration) FromString(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fromStringError[time.Duration](s, err)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// DurationWithUnit is similar to Duration, but also accepts plain integers,
+// which are interpreted in Unit, e.g. "30" is 30s when Unit is time.Second.
+type DurationWithUnit struct {
+	Value *time.Duration
+	Unit  time.Duration
+}
+
+func (d DurationWithUnit) ToString() (string, error) {
+	return d.Value.String(), nil
+}
+
+func (d DurationWithUnit) AppendString(dst []byte) ([]byte, error) {
+	return append(dst, d.Value.String()...), nil
+}
+
+func (d DurationWithUnit) FromString(s string) error {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(d.Unit) || n < math.MinInt64/int64(d.Unit) {
+			return fromStringError[time.Duration](s, strconv.ErrRange)
+		}
+		*d.Value = time.Duration(n) * d.Unit
+		return nil
+	}
+	return (*Duration)(d.Value).FromString(s)
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// Encoding is a binary-to-text encoding, e.g. *base64.Encoding,
+// *base32.Encoding and Hex.
+type Encoding interface {
+	EncodeToString(src []byte) string
+	DecodeString(s string) ([]byte, error)
+}
+
+// Hex is the hexadecimal Encoding, see package encoding/hex. The output is
+// in lower case, and both cases are accepted.
+type Hex struct{}
+
+func (Hex) EncodeToString(src []byte) string      { return hex.EncodeToString(src) }
+func (Hex) DecodeString(s string) ([]byte, error) { return hex.DecodeString(s) }
+func (Hex) AppendEncode(dst, src []byte) []byte   { return hex.AppendEncode(dst, src) }
+
+// AppendEncode appends the encoded src to dst.
+func AppendEncode(enc Encoding, dst, src []byte) []byte {
+	if appender, ok := enc.(interface{ AppendEncode(dst, src []byte) []byte }); ok {
+		return appender.AppendEncode(dst, src)
+	}
+	return append(dst, enc.EncodeToString(src)...)
+}
+
+// DecodeString decodes s with the given Encoding. When lenient is true, the
+// whitespaces in s are ignored, and the padding of base64 and base32 is
+// optional.
+func DecodeString(enc Encoding, s string, lenient bool) ([]byte, error) {
+	if !lenient {
+		return enc.DecodeString(s)
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	switch e := enc.(type) {
+	case *base64.Encoding:
+		return e.WithPadding(base64.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	case *base32.Encoding:
+		return e.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	}
+	return enc.DecodeString(s)
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MaxInputLen is the max length in bytes of the input kept in a ConvError.
+const MaxInputLen = 64
+
+// ConvError records a failed conversion between a value and a string.
+type ConvError struct {
+	Op    string       // the failed operation, "FromString" or "ToString"
+	Type  reflect.Type // the type of the value being converted
+	Input string       // the input of FromString, truncated to MaxInputLen bytes
+	Err   error        // the cause, e.g. a *strconv.NumError
+
+	redacted bool
+}
+
+// FromStringError creates a ConvError of a failed FromString call, which
+// was converting s to a value of type typ.
+func FromStringError(typ reflect.Type, s string, err error) *ConvError {
+	return &ConvError{Op: "FromString", Type: typ, Input: truncate(s), Err: err}
+}
+
+// ToStringError creates a ConvError of a failed ToString call, which was
+// converting a value of type typ to string.
+func ToStringError(typ reflect.Type, err error) *ConvError {
+	return &ConvError{Op: "ToString", Type: typ, Err: err}
+}
+
+func fromStringError[T any](s string, err error) *ConvError {
+	return FromStringError(reflect.TypeOf((*T)(nil)).Elem(), s, err)
+}
+
+func (e *ConvError) Error() string {
+	if e.Op == "ToString" {
+		return fmt.Sprintf("cannot convert %v to string: %v", e.Type, e.Err)
+	}
+	input := strconv.Quote(e.Input)
+	if e.redacted {
+		input = "[REDACTED]"
+	}
+	cause := e.Err
+	if ne, ok := cause.(*strconv.NumError); ok {
+		cause = ne.Err // the input is already in the message
+	}
+	return fmt.Sprintf("cannot convert %s to %v: %v", input, e.Type, cause)
+}
+
+func (e *ConvError) Unwrap() error {
+	return e.Err
+}
+
+// Redact removes the input from the error, so that it can be logged safely
+// when the input is sensitive, e.g. a password. The inputs of the errors it
+// wraps are removed as well, e.g. the ConvErrors of the elements of a slice.
+func (e *ConvError) Redact() {
+	e.Input = ""
+	e.redacted = true
+	redact(e.Err)
+}
+
+// redact removes the inputs of the ConvErrors and the strconv.NumErrors in
+// the tree of err.
+func redact(err error) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *ConvError:
+		e.Redact()
+		return
+	case *strconv.NumError:
+		e.Num = ""
+	case *ElemError:
+		e.redacted = e.redacted || e.Key != nil
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		redact(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			redact(err)
+		}
+	}
+}
+
+// ElemError records a failed conversion of an element of a slice or an array,
+// or of a key or a value of a map, e.g. `element 1` and `value of key "b"`.
+// The message is formatted on demand, so that ConvError.Redact can remove the
+// map key, which is a part of the input, and the inputs of the wrapped errors.
+type ElemError struct {
+	Context string  // e.g. "element 1", "key", "value of key"
+	Key     *string // the map key in the input, quoted after Context, if any
+	Err     error
+
+	redacted bool
+}
+
+// ElementError creates an ElemError of the i-th element of a slice or an array.
+func ElementError(i int, err error) *ElemError {
+	return &ElemError{Context: "element " + strconv.Itoa(i), Err: err}
+}
+
+// MapKeyError creates an ElemError of a key of a map, context is "key" or
+// "value of key".
+func MapKeyError(context, key string, err error) *ElemError {
+	return &ElemError{Context: context, Key: &key, Err: err}
+}
+
+func (e *ElemError) Error() string {
+	context := e.Context
+	if e.Key != nil {
+		if e.redacted {
+			context += " [REDACTED]"
+		} else {
+			context += " " + strconv.Quote(*e.Key)
+		}
+	}
+	return context + ": " + e.Err.Error()
+}
+
+func (e *ElemError) Unwrap() error {
+	return e.Err
+}
+
+func truncate(s string) string {
+	if len(s) <= MaxInputLen {
+		return s
+	}
+	n := MaxInputLen
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// ErrNonFinite is returned when parsing NaN or Inf with RejectNonFinite.
+var ErrNonFinite = errors.New("non-finite number")
+
+// Float is the set of the builtin floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// FloatWithOptions is similar to Float32 and Float64, but with configurable
+// format and special values.
+type FloatWithOptions[T Float] struct {
+	Value *T
+
+	// Format and Precision are the format byte and the precision used by
+	// ToString, see strconv.FormatFloat.
+	Format    byte
+	Precision int
+
+	// RejectNonFinite makes FromString return an error on NaN and ±Inf.
+	RejectNonFinite bool
+}
+
+func (f FloatWithOptions[T]) ToString() (string, error) {
+	return strconv.FormatFloat(float64(*f.Value), f.Format, f.Precision, bitSizeOfFloat[T]()), nil
+}
+
+func (f FloatWithOptions[T]) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendFloat(dst, float64(*f.Value), f.Format, f.Precision, bitSizeOfFloat[T]()), nil
+}
+
+func (f FloatWithOptions[T]) FromString(s string) error {
+	v, err := strconv.ParseFloat(s, bitSizeOfFloat[T]())
+	if err == nil && f.RejectNonFinite && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		err = ErrNonFinite
+	}
+	if err != nil {
+		return fromStringError[T](s, err)
+	}
+	*f.Value = T(v)
+	return nil
+}
+
+func bitSizeOfFloat[T Float]() int {
+	var v T
+	return int(unsafe.Sizeof(v)) * 8
+}
@@ -8,10 +8,14 @@ func (f Float32) ToString() (string, error) {
 	return strconv.FormatFloat(float64(f), 'f', -1, 32), nil
 }
 
+func (f Float32) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendFloat(dst, float64(f), 'f', -1, 32), nil
+}
+
 func (f *Float32) FromString(s string) error {
 	v, err := strconv.ParseFloat(s, 32)
 	if err != nil {
-		return err
+		return fromStringError[float32](s, err)
 	}
 	*f = Float32(v)
 	return nil
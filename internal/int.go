@@ -8,10 +8,14 @@ func (i Int) ToString() (string, error) {
 	return strconv.Itoa(int(i)), nil
 }
 
+func (i Int) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendInt(dst, int64(i), 10), nil
+}
+
 func (i *Int) FromString(s string) error {
 	v, err := strconv.Atoi(s)
 	if err != nil {
-		return err
+		return fromStringError[int](s, err)
 	}
 	*i = Int(v)
 	return nil
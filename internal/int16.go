@@ -8,10 +8,14 @@ func (i Int16) ToString() (string, error) {
 	return strconv.FormatInt(int64(i), 10), nil
 }
 
+func (i Int16) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendInt(dst, int64(i), 10), nil
+}
+
 func (i *Int16) FromString(s string) error {
 	v, err := strconv.ParseInt(s, 10, 16)
 	if err != nil {
-		return err
+		return fromStringError[int16](s, err)
 	}
 	*i = Int16(v)
 	return nil
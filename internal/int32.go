@@ -8,10 +8,14 @@ func (i Int32) ToString() (string, error) {
 	return strconv.FormatInt(int64(i), 10), nil
 }
 
+func (i Int32) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendInt(dst, int64(i), 10), nil
+}
+
 func (i *Int32) FromString(s string) error {
 	v, err := strconv.ParseInt(s, 10, 32)
 	if err != nil {
-		return err
+		return fromStringError[int32](s, err)
 	}
 	*i = Int32(v)
 	return nil
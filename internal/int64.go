@@ -8,10 +8,14 @@ func (i Int64) ToString() (string, error) {
 	return strconv.FormatInt(int64(i), 10), nil
 }
 
+func (i Int64) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendInt(dst, int64(i), 10), nil
+}
+
 func (i *Int64) FromString(s string) error {
 	v, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return err
+		return fromStringError[int64](s, err)
 	}
 	*i = Int64(v)
 	return nil
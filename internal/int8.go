@@ -8,10 +8,14 @@ func (i Int8) ToString() (string, error) {
 	return strconv.FormatInt(int64(i), 10), nil
 }
 
+func (i Int8) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendInt(dst, int64(i), 10), nil
+}
+
 func (i *Int8) FromString(s string) error {
 	v, err := strconv.ParseInt(s, 10, 8)
 	if err != nil {
-		return err
+		return fromStringError[int8](s, err)
 	}
 	*i = Int8(v)
 	return nil
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Signed is the set of the builtin signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of the builtin unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// IntWithOptions is similar to Int, Int8, ..., Int64, but with configurable
+// bases and overflow policy.
+type IntWithOptions[T Signed] struct {
+	Value *T
+
+	// ParseBase is the base of the input, see strconv.ParseInt. 0 means the
+	// base is implied by the prefix, e.g. "0x1F", "0o755", "0b1010", and
+	// underscores are permitted, e.g. "1_000_000".
+	ParseBase int
+
+	// FormatBase is the base of the output, see strconv.FormatInt. The output
+	// is prefixed with "0b", "0o" or "0x" in base 2, 8 and 16 respectively,
+	// which is also accepted by FromString in ParseBase 10.
+	FormatBase int
+
+	// Clamp makes FromString saturate the values out of range to the min or
+	// max value of T, instead of returning an error.
+	Clamp bool
+}
+
+func (i IntWithOptions[T]) ToString() (string, error) {
+	return string(i.append(nil)), nil
+}
+
+func (i IntWithOptions[T]) AppendString(dst []byte) ([]byte, error) {
+	return i.append(dst), nil
+}
+
+func (i IntWithOptions[T]) append(dst []byte) []byte {
+	v := int64(*i.Value)
+	if v < 0 && basePrefix(i.FormatBase) != "" {
+		// The sign goes before the prefix, e.g. "-0x1f".
+		dst = append(dst, '-')
+		return strconv.AppendUint(append(dst, basePrefix(i.FormatBase)...), uint64(-v), i.FormatBase)
+	}
+	return strconv.AppendInt(append(dst, basePrefix(i.FormatBase)...), v, i.FormatBase)
+}
+
+func (i IntWithOptions[T]) FromString(s string) error {
+	v, err := strconv.ParseInt(s, parseBaseOf(s, i.ParseBase, i.FormatBase), bitSizeOf[T]())
+	if err != nil && !(i.Clamp && errors.Is(err, strconv.ErrRange)) {
+		return fromStringError[T](s, err)
+	}
+	// Note: on ErrRange, v is the max or min value of the bit size.
+	*i.Value = T(v)
+	return nil
+}
+
+// UintWithOptions is the unsigned version of IntWithOptions. With Clamp,
+// the negative integers are saturated to 0.
+type UintWithOptions[T Unsigned] struct {
+	Value      *T
+	ParseBase  int
+	FormatBase int
+	Clamp      bool
+}
+
+func (u UintWithOptions[T]) ToString() (string, error) {
+	return string(u.append(nil)), nil
+}
+
+func (u UintWithOptions[T]) AppendString(dst []byte) ([]byte, error) {
+	return u.append(dst), nil
+}
+
+func (u UintWithOptions[T]) append(dst []byte) []byte {
+	return strconv.AppendUint(append(dst, basePrefix(u.FormatBase)...), uint64(*u.Value), u.FormatBase)
+}
+
+func (u UintWithOptions[T]) FromString(s string) error {
+	base := parseBaseOf(s, u.ParseBase, u.FormatBase)
+	v, err := strconv.ParseUint(s, base, bitSizeOf[T]())
+	if err != nil && u.Clamp {
+		if errors.Is(err, strconv.ErrRange) {
+			err = nil // v is the max value of the bit size
+		} else if strings.HasPrefix(s, "-") {
+			if _, serr := strconv.ParseInt(s, base, 64); serr == nil || errors.Is(serr, strconv.ErrRange) {
+				v, err = 0, nil
+			}
+		}
+	}
+	if err != nil {
+		return fromStringError[T](s, err)
+	}
+	*u.Value = T(v)
+	return nil
+}
+
+// parseBaseOf returns the base to parse s. In base 10, s is also accepted
+// with the prefix of formatBase, so that the output can be parsed back, e.g.
+// "0x1f" with formatBase 16.
+func parseBaseOf(s string, parseBase, formatBase int) int {
+	prefix := basePrefix(formatBase)
+	if parseBase != 10 || prefix == "" {
+		return parseBase
+	}
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return 0 // implied by the prefix
+	}
+	return parseBase
+}
+
+func basePrefix(base int) string {
+	switch base {
+	case 2:
+		return "0b"
+	case 8:
+		return "0o"
+	case 16:
+		return "0x"
+	}
+	return ""
+}
+
+func bitSizeOf[T Signed | Unsigned]() int {
+	var v T
+	return int(unsafe.Sizeof(v)) * 8
+}
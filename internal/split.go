@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+	ErrBadQuote          = errors.New("unexpected characters after the closing quote")
+
+	ErrMissingKeyValueSeparator = errors.New("missing key-value separator")
+)
+
+// Split slices s into all substrings separated by sep. A substring beginning
+// with the quote character is a quoted one, which ends at the next unpaired
+// quote character and can contain sep. Within a quoted substring, a pair of
+// quote characters stands for one quote character, like in CSV. The quotes
+// are kept in the results, call Unquote to remove them. Quoting is disabled
+// when quote is 0.
+//
+// Split returns an empty slice if s is empty.
+func Split(s, sep string, quote rune) ([]string, error) {
+	var parts []string
+	if s == "" {
+		return parts, nil
+	}
+	for {
+		n, err := tokenLen(s, sep, quote)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, s[:n])
+		if n == len(s) {
+			return parts, nil
+		}
+		s = s[n+len(sep):]
+	}
+}
+
+// SplitPairs slices s into key-value pairs, e.g. "k1=v1,k2=v2", where sep
+// separates the pairs and kvSep separates the key and the value of a pair.
+// Both the key and the value can be quoted, see Split. The quotes are kept in
+// the results, call Unquote to remove them.
+//
+// SplitPairs returns an empty slice if s is empty.
+func SplitPairs(s, sep, kvSep string, quote rune) ([][2]string, error) {
+	var pairs [][2]string
+	if s == "" {
+		return pairs, nil
+	}
+	for {
+		n, err := tokenLen(s, kvSep, quote)
+		if err != nil {
+			return nil, err
+		}
+		key := s[:n]
+		quoted := quote != 0 && strings.HasPrefix(key, string(quote))
+		if n == len(s) || (sep != "" && !quoted && strings.Contains(key, sep)) {
+			key, _, _ = strings.Cut(key, sep)
+			return nil, MapKeyError("key", key, ErrMissingKeyValueSeparator)
+		}
+		s = s[n+len(kvSep):]
+
+		n, err = tokenLen(s, sep, quote)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, [2]string{key, s[:n]})
+		if n == len(s) {
+			return pairs, nil
+		}
+		s = s[n+len(sep):]
+	}
+}
+
+// tokenLen returns the length of the first token in s, which ends at the
+// first sep outside of quotes or the end of s. An empty sep never matches.
+func tokenLen(s, sep string, quote rune) (int, error) {
+	if quote == 0 || !strings.HasPrefix(s, string(quote)) {
+		if i := strings.Index(s, sep); i >= 0 && sep != "" {
+			return i, nil
+		}
+		return len(s), nil
+	}
+
+	q := utf8.RuneLen(quote)
+	i := q
+	for {
+		j := strings.IndexRune(s[i:], quote)
+		if j < 0 {
+			return 0, ErrUnterminatedQuote
+		}
+		i += j + q
+		if strings.HasPrefix(s[i:], string(quote)) {
+			i += q // paired quotes
+			continue
+		}
+		if i == len(s) || strings.HasPrefix(s[i:], sep) {
+			return i, nil
+		}
+		return 0, ErrBadQuote
+	}
+}
+
+// Unquote removes the enclosing quotes of a token produced by Split or
+// SplitPairs, and replaces each pair of quote characters in it with one.
+func Unquote(s string, quote rune) string {
+	q := string(quote)
+	if quote == 0 || len(s) < 2*len(q) || !strings.HasPrefix(s, q) || !strings.HasSuffix(s, q) {
+		return s
+	}
+	return strings.ReplaceAll(s[len(q):len(s)-len(q)], q+q, q)
+}
+
+// Quote encloses s in quotes if s is empty or contains the quote character
+// or any of the specials, and doubles the quote characters in it. It's the
+// reverse operation of Unquote.
+func Quote(s string, quote rune, specials ...string) string {
+	if quote == 0 {
+		return s
+	}
+	q := string(quote)
+	needed := s == "" || strings.Contains(s, q)
+	for _, special := range specials {
+		needed = needed || strings.Contains(s, special)
+	}
+	if !needed {
+		return s
+	}
+	return q + strings.ReplaceAll(s, q, q+q) + q
+}
@@ -6,6 +6,10 @@ func (s String) ToString() (string, error) {
 	return string(s), nil
 }
 
+func (s String) AppendString(dst []byte) ([]byte, error) {
+	return append(dst, s...), nil
+}
+
 func (sv *String) FromString(s string) error {
 	*sv = String(s)
 	return nil
@@ -14,9 +14,13 @@ func (t Time) ToString() (string, error) {
 	return time.Time(t).UTC().Format(time.RFC3339Nano), nil
 }
 
+func (t Time) AppendString(dst []byte) ([]byte, error) {
+	return time.Time(t).UTC().AppendFormat(dst, time.RFC3339Nano), nil
+}
+
 func (t *Time) FromString(s string) error {
 	if dt, err := decodeTime(s); err != nil {
-		return err
+		return fromStringError[time.Time](s, err)
 	} else {
 		*t = Time(dt)
 		return nil
@@ -65,3 +69,118 @@ func decodeUnixtime(value string) (time.Time, error) {
 func nanoSecondPrecision(value string) string {
 	return value + strings.Repeat("0", 9-len(value))
 }
+
+// UnixAuto makes TimeWithOptions detect the precision of unix timestamps by
+// their magnitude, see TimeWithOptions.UnixUnit.
+const UnixAuto time.Duration = -1
+
+// TimeWithOptions is similar to Time, but with configurable layouts, location
+// and unix timestamp precision. The zero values of the fields fall back to the
+// behaviours of Time.
+type TimeWithOptions struct {
+	Value *time.Time
+
+	// Layouts are the layouts accepted by FromString, tried in order.
+	// Defaults to RFC3339Nano and "2006-01-02".
+	Layouts []string
+
+	// Format is the layout used by ToString. Defaults to RFC3339Nano.
+	Format string
+
+	// Location is used to parse the input without time zone information, and
+	// to format the output. Defaults to UTC.
+	Location *time.Location
+
+	// UnixUnit is the unit of the unix timestamps, e.g. time.Millisecond for
+	// "1618974933284". Defaults to time.Second. UnixAuto detects the unit by
+	// the magnitude of the timestamp.
+	UnixUnit time.Duration
+}
+
+func (t TimeWithOptions) ToString() (string, error) {
+	return t.Value.In(t.location()).Format(t.format()), nil
+}
+
+func (t TimeWithOptions) AppendString(dst []byte) ([]byte, error) {
+	return t.Value.In(t.location()).AppendFormat(dst, t.format()), nil
+}
+
+func (t TimeWithOptions) FromString(s string) error {
+	loc := t.location()
+	layouts := t.Layouts
+	if layouts == nil {
+		layouts = []string{time.RFC3339Nano, "2006-01-02"}
+	}
+	for _, layout := range layouts {
+		if dt, err := time.ParseInLocation(layout, s, loc); err == nil {
+			*t.Value = dt.In(loc)
+			return nil
+		}
+	}
+
+	if reUnixtime.MatchString(s) {
+		dt, err := decodeUnixtimeIn(s, t.UnixUnit)
+		if err != nil {
+			return fromStringError[time.Time](s, err)
+		}
+		*t.Value = dt.In(loc)
+		return nil
+	}
+
+	return fromStringError[time.Time](s, errors.New("invalid time value"))
+}
+
+func (t TimeWithOptions) format() string {
+	if t.Format == "" {
+		return time.RFC3339Nano
+	}
+	return t.Format
+}
+
+func (t TimeWithOptions) location() *time.Location {
+	if t.Location == nil {
+		return time.UTC
+	}
+	return t.Location
+}
+
+// decodeUnixtimeIn is similar to decodeUnixtime, but the value is in the
+// given unit. The fraction part is the fraction of the unit.
+func decodeUnixtimeIn(value string, unit time.Duration) (time.Time, error) {
+	integer, fraction, _ := strings.Cut(value, ".")
+	n, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if unit == UnixAuto {
+		unit = unixUnitOf(n)
+	}
+	if unit == 0 {
+		unit = time.Second
+	}
+
+	perSecond := int64(time.Second / unit)
+	sec, nsec := n/perSecond, n%perSecond*int64(unit)
+	if fraction != "" {
+		// Note: errors are ignored, since we already validated the value.
+		f, _ := strconv.ParseInt(nanoSecondPrecision(fraction), 10, 64)
+		nsec += f * int64(unit) / int64(time.Second)
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// unixUnitOf guesses the unit of a unix timestamp by its magnitude, e.g.
+// 1618974933 is in seconds, and 1618974933284 is in milliseconds. The
+// timestamps in seconds are supported up to the year 5138.
+func unixUnitOf(n int64) time.Duration {
+	switch {
+	case n < 1e11:
+		return time.Second
+	case n < 1e14:
+		return time.Millisecond
+	case n < 1e17:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
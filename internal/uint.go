@@ -8,10 +8,14 @@ func (u Uint) ToString() (string, error) {
 	return strconv.FormatUint(uint64(u), 10), nil
 }
 
+func (u Uint) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendUint(dst, uint64(u), 10), nil
+}
+
 func (u *Uint) FromString(s string) error {
 	v, err := strconv.ParseUint(s, 10, 64)
 	if err != nil {
-		return err
+		return fromStringError[uint](s, err)
 	}
 	*u = Uint(v)
 	return nil
@@ -8,10 +8,14 @@ func (u Uint16) ToString() (string, error) {
 	return strconv.FormatUint(uint64(u), 10), nil
 }
 
+func (u Uint16) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendUint(dst, uint64(u), 10), nil
+}
+
 func (u *Uint16) FromString(s string) error {
 	v, err := strconv.ParseUint(s, 10, 16)
 	if err != nil {
-		return err
+		return fromStringError[uint16](s, err)
 	}
 	*u = Uint16(v)
 	return nil
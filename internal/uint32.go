@@ -8,10 +8,14 @@ func (u Uint32) ToString() (string, error) {
 	return strconv.FormatUint(uint64(u), 10), nil
 }
 
+func (u Uint32) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendUint(dst, uint64(u), 10), nil
+}
+
 func (u *Uint32) FromString(s string) error {
 	v, err := strconv.ParseUint(s, 10, 32)
 	if err != nil {
-		return err
+		return fromStringError[uint32](s, err)
 	}
 	*u = Uint32(v)
 	return nil
@@ -8,10 +8,14 @@ func (u Uint64) ToString() (string, error) {
 	return strconv.FormatUint(uint64(u), 10), nil
 }
 
+func (u Uint64) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendUint(dst, uint64(u), 10), nil
+}
+
 func (u *Uint64) FromString(s string) error {
 	v, err := strconv.ParseUint(s, 10, 64)
 	if err != nil {
-		return err
+		return fromStringError[uint64](s, err)
 	}
 	*u = Uint64(v)
 	return nil
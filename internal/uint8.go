@@ -8,10 +8,14 @@ func (u Uint8) ToString() (string, error) {
 	return strconv.FormatUint(uint64(u), 10), nil
 }
 
+func (u Uint8) AppendString(dst []byte) ([]byte, error) {
+	return strconv.AppendUint(dst, uint64(u), 10), nil
+}
+
 func (u *Uint8) FromString(s string) error {
 	v, err := strconv.ParseUint(s, 10, 8)
 	if err != nil {
-		return err
+		return fromStringError[uint8](s, err)
 	}
 	*u = Uint8(v)
 	return nil
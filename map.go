@@ -0,0 +1,103 @@
+package strconvx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
+// mapCodec is a StringCodec of maps. It converts the keys and the values with
+// the StringCodecs of their types, which are created by the same Namespace,
+// e.g. "env=prod,region=eu". The entries are sorted by the string
+// representations of the keys, so the output is deterministic.
+type mapCodec struct {
+	ns   *Namespace
+	rv   reflect.Value // the map
+	opts *options
+}
+
+func (c *Namespace) createMapStringCodec(rv reflect.Value, opts *options) (StringCodec, error) {
+	return &mapCodec{ns: c, rv: rv.Elem(), opts: opts}, nil
+}
+
+func (mc *mapCodec) ToString() (string, error) {
+	type entry struct{ key, value string }
+	entries := make([]entry, 0, mc.rv.Len())
+	iter := mc.rv.MapRange()
+	for iter.Next() {
+		key, err := mc.entryToString(iter.Key())
+		if err != nil {
+			return "", internal.ToStringError(mc.rv.Type(), fmt.Errorf("key %v: %w", iter.Key(), err))
+		}
+		value, err := mc.entryToString(iter.Value())
+		if err != nil {
+			return "", internal.ToStringError(mc.rv.Type(), fmt.Errorf("value of key %q: %w", key, err))
+		}
+		entries = append(entries, entry{key, value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	var sb strings.Builder
+	for i, e := range entries {
+		if i > 0 {
+			sb.WriteString(mc.opts.Separator)
+		}
+		sb.WriteString(mc.quote(e.key))
+		sb.WriteString(mc.opts.KeyValueSeparator)
+		sb.WriteString(mc.quote(e.value))
+	}
+	return sb.String(), nil
+}
+
+func (mc *mapCodec) FromString(s string) error {
+	pairs, err := internal.SplitPairs(s, mc.opts.Separator, mc.opts.KeyValueSeparator, mc.opts.Quote)
+	if err != nil {
+		return internal.FromStringError(mc.rv.Type(), s, err)
+	}
+
+	mapType := mc.rv.Type()
+	newValue := reflect.MakeMapWithSize(mapType, len(pairs))
+	for _, pair := range pairs {
+		key := internal.Unquote(pair[0], mc.opts.Quote)
+		rvKey := reflect.New(mapType.Key()).Elem()
+		if err := mc.entryFromString(rvKey, key); err != nil {
+			return internal.FromStringError(mapType, s, internal.MapKeyError("key", key, err))
+		}
+		rvValue := reflect.New(mapType.Elem()).Elem()
+		if err := mc.entryFromString(rvValue, internal.Unquote(pair[1], mc.opts.Quote)); err != nil {
+			return internal.FromStringError(mapType, s, internal.MapKeyError("value of key", key, err))
+		}
+		newValue.SetMapIndex(rvKey, rvValue)
+	}
+	mc.rv.Set(newValue)
+	return nil
+}
+
+func (mc *mapCodec) quote(s string) string {
+	return internal.Quote(s, mc.opts.Quote, mc.opts.Separator, mc.opts.KeyValueSeparator)
+}
+
+// entryToString converts a key or a value of the map to string. Since they
+// are not addressable, they will be copied before the conversion.
+func (mc *mapCodec) entryToString(rv reflect.Value) (string, error) {
+	copied := reflect.New(rv.Type())
+	copied.Elem().Set(rv)
+	codec, err := mc.ns.createStringCodec(copied, mc.opts)
+	if err != nil {
+		return "", err
+	}
+	return codec.ToString()
+}
+
+func (mc *mapCodec) entryFromString(rv reflect.Value, s string) error {
+	codec, err := mc.ns.createStringCodec(rv.Addr(), mc.opts)
+	if err != nil {
+		return err
+	}
+	return codec.FromString(s)
+}
@@ -0,0 +1,125 @@
+package strconvx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapCodec_StringString(t *testing.T) {
+	var labels map[string]string
+	sv, err := New(&labels)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("env=prod,region=eu"))
+	assert.Equal(t, map[string]string{"env": "prod", "region": "eu"}, labels)
+
+	labels = map[string]string{"z": "1", "a": "x,y", "m": "k=v", "": `"`}
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `""="""",a="x,y",m="k=v",z=1`, got)
+	assert.NoError(t, sv.FromString(got))
+	assert.Equal(t, map[string]string{"z": "1", "a": "x,y", "m": "k=v", "": `"`}, labels)
+
+	assert.NoError(t, sv.FromString(""))
+	assert.Equal(t, map[string]string{}, labels)
+
+	// Only the first unquoted separator splits the key and the value.
+	assert.NoError(t, sv.FromString("a=b=c"))
+	assert.Equal(t, map[string]string{"a": "b=c"}, labels)
+
+	assert.ErrorContains(t, sv.FromString("env=prod,region"), `key "region": missing key-value separator`)
+	assert.ErrorContains(t, sv.FromString("env,region=eu"), `key "env": missing key-value separator`)
+	assert.ErrorContains(t, sv.FromString("env=prod,"), `key "": missing key-value separator`)
+	assert.ErrorContains(t, sv.FromString(`"env=prod`), "unterminated quote")
+	assert.Equal(t, map[string]string{"a": "b=c"}, labels, "should not change on error")
+}
+
+func TestMapCodec_Deterministic(t *testing.T) {
+	m := map[int]string{}
+	for i := 0; i < 100; i++ {
+		m[i] = "v"
+	}
+	sv, err := New(&m)
+	assert.NoError(t, err)
+	first, err := sv.ToString()
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		got, err := sv.ToString()
+		assert.NoError(t, err)
+		assert.Equal(t, first, got)
+	}
+}
+
+func TestMapCodec_Options(t *testing.T) {
+	var flags map[int]bool
+	sv, err := NewNamespace().New(&flags, Separator(";"), KeyValueSeparator(":"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("1:true;2:false"))
+	assert.Equal(t, map[int]bool{1: true, 2: false}, flags)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1:true;2:false", got)
+
+	assert.ErrorContains(t, sv.FromString("1:true;x:false"), `key "x"`)
+	assert.ErrorContains(t, sv.FromString("1:true;2:maybe"), `value of key "2"`)
+}
+
+func TestMapCodec_NamespaceOptions(t *testing.T) {
+	ns := NewNamespace()
+	ns.SetOptions(Separator(";"), KeyValueSeparator(":"))
+
+	var flags map[int]bool
+	sv, err := ns.New(&flags)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("1:true;2:false"))
+	assert.Equal(t, map[int]bool{1: true, 2: false}, flags)
+
+	// The options passed to New take precedence.
+	sv, err = ns.New(&flags, KeyValueSeparator("="))
+	assert.NoError(t, err)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1=true;2=false", got)
+}
+
+func TestMapCodec_SliceValues(t *testing.T) {
+	var groups map[string][]int
+	sv, err := New(&groups)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString(`a="1,2",b=3`))
+	assert.Equal(t, map[string][]int{"a": {1, 2}, "b": {3}}, groups)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `a="1,2",b=3`, got)
+}
+
+func TestMapCodec_ErrUnsupportedType(t *testing.T) {
+	var m1 map[StructNotStringConvertable]string
+	sv, err := New(&m1)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sv)
+
+	var m2 map[string]StructNotStringConvertable
+	sv, err = New(&m2)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sv)
+}
+
+func TestMapCodec_SelfReferential(t *testing.T) {
+	type Tree map[string]Tree
+	tree := Tree{"a": Tree{}, "b": nil}
+	sv, err := New(&tree)
+	assert.NoError(t, err)
+	assert.Equal(t, `a="",b=""`, must(sv.ToString()))
+	assert.NoError(t, sv.FromString("x=,y="))
+	assert.Len(t, tree, 2)
+	assert.Contains(t, tree, "x")
+
+	type Bad map[StructNotStringConvertable]Bad
+	var b Bad
+	_, err = New(&b)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
@@ -3,6 +3,8 @@ package strconvx
 import (
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ggicci/strconvx/internal"
@@ -13,39 +15,118 @@ import (
 var defaultNS = NewNamespace()
 
 // Namespace is the place to register type adaptors (of AnyAdaptor).
+// A Namespace is safe for concurrent use by multiple goroutines.
+//
+// The way to convert a type is resolved on the first New call on the type and
+// cached in the namespace, until any adaptor is registered or removed in the
+// namespace or its ancestors.
 type Namespace struct {
-	adaptors map[reflect.Type]AnyAdaptor
+	parent     *Namespace
+	mu         sync.RWMutex
+	adaptors   map[reflect.Type]AnyAdaptor
+	masked     map[reflect.Type]struct{}
+	interfaces []interfaceAdaptor
+	kinds      map[reflect.Kind]AnyAdaptor
+	funcs      []funcAdaptor
+	defaults   atomic.Pointer[[]Option]      // set by SetOptions, read without the lock
+	strategies atomic.Pointer[strategyCache] // copy-on-write, read without the lock
+	version    atomic.Uint64                 // increased on every change of the adaptors
+}
+
+type interfaceAdaptor struct {
+	iface   reflect.Type
+	adaptor AnyAdaptor
+}
+
+type funcAdaptor struct {
+	handle  FuncAdaptorHandle
+	match   func(reflect.Type) bool
+	adaptor AnyAdaptor
+}
+
+// FuncAdaptorHandle identifies a predicate adaptor registered by AdaptFunc,
+// which can be removed by UndoAdaptFunc.
+type FuncAdaptorHandle struct {
+	id uint64
+}
+
+// funcAdaptorIDs generates the ids of FuncAdaptorHandles, which are unique
+// across the namespaces.
+var funcAdaptorIDs atomic.Uint64
+
+// NamespaceOption configures a Namespace created by NewNamespace.
+type NamespaceOption func(*Namespace)
+
+// WithParent makes the new namespace a child of the given parent namespace.
+// A child namespace inherits the adaptors and the default options of its
+// ancestors, i.e. the adaptors are looked up in the order of child, parent,
+// grandparent, ..., and the builtin adaptors. The registrations in the child
+// never affect its ancestors.
+func WithParent(parent *Namespace) NamespaceOption {
+	return func(c *Namespace) {
+		c.parent = parent
+	}
 }
 
 // NewNamespace creates a namespace where you can register adaptors to
 // override/adapt the converting behaviours of existing types.
-func NewNamespace() *Namespace {
-	return &Namespace{
+func NewNamespace(opts ...NamespaceOption) *Namespace {
+	c := &Namespace{
 		adaptors: make(map[reflect.Type]AnyAdaptor),
+		masked:   make(map[reflect.Type]struct{}),
+		kinds:    make(map[reflect.Kind]AnyAdaptor),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Derive creates a child namespace of c. It's a shortcut to
+// NewNamespace(WithParent(c)).
+func (c *Namespace) Derive() *Namespace {
+	return NewNamespace(WithParent(c))
+}
+
+// Parent returns the parent namespace of c, nil if c has no parent.
+func (c *Namespace) Parent() *Namespace {
+	return c.parent
 }
 
 // New creates a StringCodec instance from the given value. If the given value itself
 // is already a StringCodec, it will return directly. Otherwise, it will try to create
 // a StringCodec instance by trying the following approaches:
-//  1. check if there's a custom adaptor for the type of the given value,
-//     if so, use it to adapt the given value to a StringCodec.
+//  1. check if there's a custom adaptor for the type of the given value in the
+//     namespace or its ancestors, if so, use it to adapt the given value to a
+//     StringCodec. Then check the interface adaptors, the kind adaptors and the
+//     predicate adaptors in order, see AdaptInterface, AdaptKind and AdaptFunc.
+//     All these adaptors registered in the namespace take precedence over the
+//     following approaches.
 //  2. same as above, but check the builtin adaptors, which support the builtin types,
 //     e.g. int, string, float64, etc.
 //  3. try to create a "hybrid" instance, which makes use of the methods FromString,
 //     ToString, MarshalText and UnmarshalText to fullfill the StringCodec interface.
+//  4. if the underlying type of the given value is a builtin type, e.g.
+//     "type UserID int64", use the builtin adaptor of the underlying type.
+//  5. if the given value is a pointer to a pointer, e.g. **int, convert the
+//     pointed value, and allocate the pointer on demand. A nil pointer is
+//     converted to the null representation, see Null.
+//  6. if the given value is a slice or an array, convert it element by element,
+//     where the elements are joined by a separator, see Separator and Quote.
+//  7. if the given value is a map, convert it entry by entry, where the keys
+//     and the values are joined by KeyValueSeparator, e.g. "env=prod,region=eu".
 //
-// It has three options:
+// The hybrid behaviour is controlled by the options:
 //
 //	New(v)
 //
-// 1. with only default options, it will try all the 3 ways as listed above to
-// create a StringCodec.
+// 1. with only default options, it will try all the 7 ways as listed above in
+// order to create a StringCodec.
 //
 //	New(v, NoHybrid())
 //
-// 2. without hybrid, i.e. won't try the 3rd method, returns an
-// ErrUnsupportedType error.
+// 2. without hybrid, i.e. won't try the 3rd way but still tries all the
+// others, returns an ErrUnsupportedType error if none of them works.
 //
 //	New(v, CompleteHybrid())
 //
@@ -59,11 +140,63 @@ func (c *Namespace) New(v any, opts ...Option) (StringCodec, error) {
 		return vs, nil
 	}
 
-	options := defaultOptions()
+	return c.createStringCodec(v, c.options(opts))
+}
+
+// options returns the options of a New call, i.e. the given options applied
+// after the default options of the namespace. The New calls without any
+// options share noOptions, so that they don't allocate.
+func (c *Namespace) options(opts []Option) *options {
+	if len(opts) == 0 && !c.hasDefaults() {
+		return noOptions
+	}
+	o := defaultOptions()
+	c.applyDefaults(o)
 	for _, opt := range opts {
-		opt(options)
+		opt(o)
 	}
-	return c.createStringCodec(v, options)
+	return o
+}
+
+// noOptions are the options of the New calls without any options, which
+// must not be modified.
+var noOptions = defaultOptions()
+
+// SetOptions sets the default options of the namespace, which are applied to
+// every New call before the options passed to New. Calling it again replaces
+// the previous default options. The default options of a child namespace are
+// applied after the ones of its ancestors.
+//
+// Example:
+//
+//	ns := strconvx.NewNamespace()
+//	ns.SetOptions(strconvx.Separator(";"), strconvx.KeyValueSeparator(":"))
+//	ns.New(&m) // "1:true;2:false" <=> map[int]bool{1: true, 2: false}
+func (c *Namespace) SetOptions(opts ...Option) {
+	c.defaults.Store(&opts)
+}
+
+// applyDefaults applies the default options of the ancestors and c in order.
+func (c *Namespace) applyDefaults(o *options) {
+	if c.parent != nil {
+		c.parent.applyDefaults(o)
+	}
+
+	if defaults := c.defaults.Load(); defaults != nil {
+		for _, opt := range *defaults {
+			opt(o)
+		}
+	}
+}
+
+// hasDefaults reports whether c or any of its ancestors has default options.
+func (c *Namespace) hasDefaults() bool {
+	for ns := c; ns != nil; ns = ns.parent {
+		if defaults := ns.defaults.Load(); defaults != nil && len(*defaults) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Namespace) createStringCodec(v any, opts *options) (StringCodec, error) {
@@ -78,32 +211,11 @@ func (c *Namespace) createStringCodec(v any, opts *options) (StringCodec, error)
 		return nil, fmt.Errorf("%w: value must be a non-nil pointer", ErrNilPointer)
 	}
 
-	baseType := rv.Type().Elem()
-
-	// Check if there is a custom adaptor for the base type.
-	if adapt, ok := c.adaptors[baseType]; ok {
-		return adapt(rv.Interface())
-	}
-
-	// Check if there is a built-in adaptor for the base type.
-	if adapt, ok := builtinAdaptors[baseType]; ok {
-		return adapt(rv.Interface())
-	}
-
-	// Try to create a hybrid StringCodec from the reflect.Value.
-	if !opts.Has(optionNoHybrid) {
-		h := createHybridStringCodec(rv)
-		if h != nil {
-			if opts.Has(optionCompleteHybrid) {
-				if err := h.(*hybrid).validateAsComplete(); err != nil {
-					return nil, err
-				}
-			}
-			return h, nil
-		}
+	s, err := c.strategyOf(rv.Type(), opts)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, unsupportedType(baseType)
+	return s(rv, opts)
 }
 
 // Adapt registers a custom adaptor for the given type.
@@ -119,12 +231,251 @@ func (c *Namespace) createStringCodec(v any, opts *options) (StringCodec, error)
 //	})
 //	ns.Adapt(typ, adaptor)
 func (c *Namespace) Adapt(typ reflect.Type, adaptor AnyAdaptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
 	c.adaptors[typ] = adaptor
+	delete(c.masked, typ)
 }
 
 // UndoAdapt removes the custom adaptor for the given type. It's a reverse operation of Adapt.
+//
+// For a child namespace (see WithParent), UndoAdapt also masks the adaptors
+// registered by Adapt for the given type in its ancestors, i.e. the type will
+// be converted as if no adaptors were registered for the exact type. The
+// interface, kind and predicate adaptors of the namespace and its ancestors
+// still apply to the type, see AdaptInterface, AdaptKind and AdaptFunc. Call
+// Adapt to register a new adaptor and remove the mask.
 func (c *Namespace) UndoAdapt(typ reflect.Type) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
 	delete(c.adaptors, typ)
+	if c.parent != nil {
+		c.masked[typ] = struct{}{}
+	}
+}
+
+// Lookup returns the custom adaptor for the given type, and the namespace in
+// the chain of c and its ancestors where the adaptor was registered. Returns
+// nil and nil if there's no such adaptor, or it's masked by UndoAdapt.
+func (c *Namespace) Lookup(typ reflect.Type) (AnyAdaptor, *Namespace) {
+	for ns := c; ns != nil; ns = ns.parent {
+		ns.mu.RLock()
+		adapt, ok := ns.adaptors[typ]
+		_, masked := ns.masked[typ]
+		ns.mu.RUnlock()
+
+		if ok {
+			return adapt, ns
+		}
+		if masked {
+			break
+		}
+	}
+	return nil, nil
+}
+
+// AdaptInterface registers a custom adaptor for all the types implementing
+// the given interface, e.g. fmt.Stringer. The adaptor receives a pointer to
+// the value, and a type implements the interface if the pointer to it does.
+//
+// The interface adaptors are checked after the adaptors registered by Adapt
+// for the exact types, and before the kind adaptors, see AdaptKind. When multiple
+// interface adaptors match a type, the first registered one wins, and the
+// ones of a namespace win over the ones of its ancestors. Registering an
+// adaptor for the same interface again replaces the previous one, but keeps
+// its position.
+//
+// Example:
+//
+//	type Enum interface {
+//		String() string
+//		Parse(string) error
+//	}
+//
+//	ns.AdaptInterface(reflect.TypeOf((*Enum)(nil)).Elem(), func(v any) (strconvx.StringCodec, error) {
+//		return &EnumCodec{v.(Enum)}, nil
+//	})
+func (c *Namespace) AdaptInterface(iface reflect.Type, adaptor AnyAdaptor) {
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Errorf("strconvx: %v is not an interface type", iface))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	// Copy on write, since lookupInterface iterates the slice without the lock.
+	interfaces := make([]interfaceAdaptor, 0, len(c.interfaces)+1)
+	replaced := false
+	for _, ia := range c.interfaces {
+		if ia.iface == iface {
+			ia.adaptor = adaptor
+			replaced = true
+		}
+		interfaces = append(interfaces, ia)
+	}
+	if !replaced {
+		interfaces = append(interfaces, interfaceAdaptor{iface, adaptor})
+	}
+	c.interfaces = interfaces
+}
+
+// UndoAdaptInterface removes the interface adaptor for the given interface.
+// It's a reverse operation of AdaptInterface.
+func (c *Namespace) UndoAdaptInterface(iface reflect.Type) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	for i := range c.interfaces {
+		if c.interfaces[i].iface == iface {
+			// Copy on write, see AdaptInterface.
+			c.interfaces = append(c.interfaces[:i:i], c.interfaces[i+1:]...)
+			return
+		}
+	}
+}
+
+// lookupInterface returns the first interface adaptor whose interface is
+// implemented by the given type, see AdaptInterface.
+func (c *Namespace) lookupInterface(typ reflect.Type) AnyAdaptor {
+	for ns := c; ns != nil; ns = ns.parent {
+		ns.mu.RLock()
+		interfaces := ns.interfaces
+		ns.mu.RUnlock()
+
+		for _, ia := range interfaces {
+			if typ.Implements(ia.iface) {
+				return ia.adaptor
+			}
+		}
+	}
+	return nil
+}
+
+// AdaptKind registers a custom adaptor for all the types of the given kind,
+// e.g. reflect.Float64 for float64 and "type Celsius float64". The adaptor
+// receives a pointer to the value. The kind adaptors are checked after the
+// interface adaptors, see AdaptInterface. The ones of a namespace win over the
+// ones of its ancestors.
+//
+// Example:
+//
+//	// all float64 values are formatted with 2 decimal places
+//	ns.AdaptKind(reflect.Float64, func(v any) (strconvx.StringCodec, error) {
+//		return &FixedFloat{Value: reflect.ValueOf(v).Elem(), Prec: 2}, nil
+//	})
+func (c *Namespace) AdaptKind(kind reflect.Kind, adaptor AnyAdaptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	c.kinds[kind] = adaptor
+}
+
+// UndoAdaptKind removes the kind adaptor for the given kind. It's a reverse
+// operation of AdaptKind.
+func (c *Namespace) UndoAdaptKind(kind reflect.Kind) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	delete(c.kinds, kind)
+}
+
+// AdaptFunc registers a custom adaptor for all the types that match the given
+// predicate. The adaptor receives a pointer to the value. The predicate
+// adaptors are checked after the kind adaptors, see AdaptKind. When multiple
+// predicate adaptors match a type, the first registered one wins, and the
+// ones of a namespace win over the ones of its ancestors.
+//
+// Example:
+//
+//	// all slices whose elements implement Enum
+//	ns.AdaptFunc(func(typ reflect.Type) bool {
+//		return typ.Kind() == reflect.Slice && reflect.PointerTo(typ.Elem()).Implements(enumType)
+//	}, enumSliceAdaptor)
+//
+// The returned handle removes the adaptor with UndoAdaptFunc.
+func (c *Namespace) AdaptFunc(match func(reflect.Type) bool, adaptor AnyAdaptor) FuncAdaptorHandle {
+	handle := FuncAdaptorHandle{funcAdaptorIDs.Add(1)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	// Copy on write, see AdaptInterface.
+	funcs := make([]funcAdaptor, len(c.funcs), len(c.funcs)+1)
+	copy(funcs, c.funcs)
+	c.funcs = append(funcs, funcAdaptor{handle, match, adaptor})
+	return handle
+}
+
+// UndoAdaptFunc removes the predicate adaptor registered by AdaptFunc in c,
+// which returned the given handle. It's a reverse operation of AdaptFunc.
+func (c *Namespace) UndoAdaptFunc(handle FuncAdaptorHandle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version.Add(1)
+	for i := range c.funcs {
+		if c.funcs[i].handle == handle {
+			// Copy on write, see AdaptInterface.
+			c.funcs = append(c.funcs[:i:i], c.funcs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Namespace) lookupKind(kind reflect.Kind) AnyAdaptor {
+	for ns := c; ns != nil; ns = ns.parent {
+		ns.mu.RLock()
+		adapt := ns.kinds[kind]
+		ns.mu.RUnlock()
+
+		if adapt != nil {
+			return adapt
+		}
+	}
+	return nil
+}
+
+func (c *Namespace) lookupFunc(typ reflect.Type) AnyAdaptor {
+	for ns := c; ns != nil; ns = ns.parent {
+		ns.mu.RLock()
+		funcs := ns.funcs
+		ns.mu.RUnlock()
+
+		for _, fa := range funcs {
+			if fa.match(typ) {
+				return fa.adaptor
+			}
+		}
+	}
+	return nil
+}
+
+// lookupCustom returns the custom adaptor for the given type registered by
+// Adapt, AdaptInterface, AdaptKind or AdaptFunc in c and its ancestors, in
+// the order of precedence. Returns nil if there's no such adaptor.
+func (c *Namespace) lookupCustom(typ reflect.Type) AnyAdaptor {
+	if adapt, _ := c.Lookup(typ); adapt != nil {
+		return adapt
+	}
+	if adapt := c.lookupInterface(reflect.PointerTo(typ)); adapt != nil {
+		return adapt
+	}
+	if adapt := c.lookupKind(typ.Kind()); adapt != nil {
+		return adapt
+	}
+	return c.lookupFunc(typ)
+}
+
+// usesBuiltin reports whether the values of typ are converted by the builtin
+// adaptor with the default options in c, i.e. typ is a builtin type, and
+// there are neither adaptors for typ nor default options in c and its
+// ancestors.
+func (c *Namespace) usesBuiltin(typ reflect.Type) bool {
+	if _, ok := builtinAdaptors[typ]; !ok {
+		return false
+	}
+	return !c.hasDefaults() && c.lookupCustom(typ) == nil
 }
 
 func unsupportedType(rt reflect.Type) error {
@@ -133,21 +484,41 @@ func unsupportedType(rt reflect.Type) error {
 
 func init() {
 	builtinAdaptor(func(v *string) (StringCodec, error) { return (*internal.String)(v), nil })
-	builtinAdaptor(func(v *bool) (StringCodec, error) { return (*internal.Bool)(v), nil })
-	builtinAdaptor(func(v *int) (StringCodec, error) { return (*internal.Int)(v), nil })
-	builtinAdaptor(func(v *int8) (StringCodec, error) { return (*internal.Int8)(v), nil })
-	builtinAdaptor(func(v *int16) (StringCodec, error) { return (*internal.Int16)(v), nil })
-	builtinAdaptor(func(v *int32) (StringCodec, error) { return (*internal.Int32)(v), nil })
-	builtinAdaptor(func(v *int64) (StringCodec, error) { return (*internal.Int64)(v), nil })
-	builtinAdaptor(func(v *uint) (StringCodec, error) { return (*internal.Uint)(v), nil })
-	builtinAdaptor(func(v *uint8) (StringCodec, error) { return (*internal.Uint8)(v), nil })
-	builtinAdaptor(func(v *uint16) (StringCodec, error) { return (*internal.Uint16)(v), nil })
-	builtinAdaptor(func(v *uint32) (StringCodec, error) { return (*internal.Uint32)(v), nil })
-	builtinAdaptor(func(v *uint64) (StringCodec, error) { return (*internal.Uint64)(v), nil })
-	builtinAdaptor(func(v *float32) (StringCodec, error) { return (*internal.Float32)(v), nil })
-	builtinAdaptor(func(v *float64) (StringCodec, error) { return (*internal.Float64)(v), nil })
+	builtinAdaptorWithOptions(createBoolStringCodec)
+	builtinAdaptorWithOptions(signedAdaptor(func(v *int) StringCodec { return (*internal.Int)(v) }))
+	builtinAdaptorWithOptions(signedAdaptor(func(v *int8) StringCodec { return (*internal.Int8)(v) }))
+	builtinAdaptorWithOptions(signedAdaptor(func(v *int16) StringCodec { return (*internal.Int16)(v) }))
+	builtinAdaptorWithOptions(signedAdaptor(func(v *int32) StringCodec { return (*internal.Int32)(v) }))
+	builtinAdaptorWithOptions(signedAdaptor(func(v *int64) StringCodec { return (*internal.Int64)(v) }))
+	builtinAdaptorWithOptions(unsignedAdaptor(func(v *uint) StringCodec { return (*internal.Uint)(v) }))
+	builtinAdaptorWithOptions(unsignedAdaptor(func(v *uint8) StringCodec { return (*internal.Uint8)(v) }))
+	builtinAdaptorWithOptions(unsignedAdaptor(func(v *uint16) StringCodec { return (*internal.Uint16)(v) }))
+	builtinAdaptorWithOptions(unsignedAdaptor(func(v *uint32) StringCodec { return (*internal.Uint32)(v) }))
+	builtinAdaptorWithOptions(unsignedAdaptor(func(v *uint64) StringCodec { return (*internal.Uint64)(v) }))
+	builtinAdaptorWithOptions(floatAdaptor(func(v *float32) StringCodec { return (*internal.Float32)(v) }))
+	builtinAdaptorWithOptions(floatAdaptor(func(v *float64) StringCodec { return (*internal.Float64)(v) }))
 	builtinAdaptor(func(v *complex64) (StringCodec, error) { return (*internal.Complex64)(v), nil })
 	builtinAdaptor(func(v *complex128) (StringCodec, error) { return (*internal.Complex128)(v), nil })
-	builtinAdaptor(func(v *time.Time) (StringCodec, error) { return (*internal.Time)(v), nil })
-	builtinAdaptor(func(b *[]byte) (StringCodec, error) { return (*internal.ByteSlice)(b), nil })
+	builtinAdaptorWithOptions(func(v *time.Time, o *options) (StringCodec, error) {
+		if o.TimeLayouts != nil || o.TimeFormat != "" || o.TimeLocation != nil || o.UnixPrecision != 0 {
+			if err := o.validateUnixPrecision(); err != nil {
+				return nil, err
+			}
+			return &internal.TimeWithOptions{
+				Value:    v,
+				Layouts:  o.TimeLayouts,
+				Format:   o.TimeFormat,
+				Location: o.TimeLocation,
+				UnixUnit: o.UnixPrecision,
+			}, nil
+		}
+		return (*internal.Time)(v), nil
+	})
+	builtinAdaptorWithOptions(func(v *time.Duration, o *options) (StringCodec, error) {
+		if o.DurationUnit > 0 {
+			return &internal.DurationWithUnit{Value: v, Unit: o.DurationUnit}, nil
+		}
+		return (*internal.Duration)(v), nil
+	})
+	builtinAdaptorWithOptions(createByteSliceStringCodec)
 }
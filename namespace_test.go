@@ -1,8 +1,13 @@
 package strconvx
 
 import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/ggicci/strconvx/internal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +109,393 @@ func TestNamespace_NewWithCompleteHybridOption(t *testing.T) {
 	assert.NotNil(t, sb)
 	assert.NoError(t, err)
 }
+
+func TestNamespace_NamedBuiltinTypePrecedence(t *testing.T) {
+	ns := NewNamespace()
+
+	// Hybrid methods on the named type take precedence.
+	var yesno YesNo
+	sb, err := ns.New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, bool(yesno))
+	assert.Error(t, sb.FromString("true"))
+
+	// Custom adaptors take precedence.
+	type Switch bool
+	typ, adaptor := ToAnyAdaptor(func(b *Switch) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+	var sw Switch
+	sb, err = ns.New(&sw)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, bool(sw))
+
+	// Without custom adaptors, use the builtin adaptor of the underlying type.
+	ns.UndoAdapt(typ)
+	sb, err = ns.New(&sw, NoHybrid())
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("false"))
+	assert.False(t, bool(sw))
+	assert.Error(t, sb.FromString("yes"))
+}
+
+func TestNamespace_ConcurrentAdaptAndNew(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ns.Adapt(typ, adaptor)
+				ns.SetOptions(Separator(";"))
+				ns.UndoAdapt(typ)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var flags []bool
+				sb, err := ns.New(&flags)
+				assert.NoError(t, err)
+				assert.NotNil(t, sb)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNamespace_NewAllocations(t *testing.T) {
+	ns := NewNamespace()
+	var i int
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ns.Lookup(typeOf[int]())
+		_, _ = ns.New(&i)
+	})
+	// Only the options are allocated, the lookups are allocation-free.
+	assert.LessOrEqual(t, allocs, 1.0)
+}
+
+func TestNamespace_Derive(t *testing.T) {
+	base := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	base.Adapt(typ, adaptor)
+	base.SetOptions(Separator(";"))
+
+	child := base.Derive()
+	assert.Same(t, base, child.Parent())
+	assert.Nil(t, base.Parent())
+
+	// Inherit the adaptors and the default options of the parent.
+	flags, err := ParseIn[[]bool](child, "yes;no")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, flags)
+
+	adapt, owner := child.Lookup(typ)
+	assert.NotNil(t, adapt)
+	assert.Same(t, base, owner)
+
+	// Override the parent's adaptor in the child only.
+	child.Adapt(typ, func(v any) (StringCodec, error) {
+		return (*YesNo)(v.(*bool)), nil
+	})
+	_, owner = child.Lookup(typ)
+	assert.Same(t, child, owner)
+	_, owner = base.Lookup(typ)
+	assert.Same(t, base, owner)
+}
+
+func TestNamespace_WithParent_Chain(t *testing.T) {
+	root := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	root.Adapt(typ, adaptor)
+	root.SetOptions(Separator(";"), KeyValueSeparator(":"))
+
+	parent := NewNamespace(WithParent(root))
+	parent.SetOptions(Separator("|"))
+	child := NewNamespace(WithParent(parent))
+
+	_, owner := child.Lookup(typ)
+	assert.Same(t, root, owner)
+
+	s, err := FormatIn(child, map[string]bool{"a": true, "b": false})
+	assert.NoError(t, err)
+	assert.Equal(t, "a:yes|b:no", s)
+}
+
+func TestNamespace_UndoAdapt_Mask(t *testing.T) {
+	base := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	base.Adapt(typ, adaptor)
+
+	child := base.Derive()
+	child.UndoAdapt(typ)
+	adapt, owner := child.Lookup(typ)
+	assert.Nil(t, adapt)
+	assert.Nil(t, owner)
+
+	// Falls back to the builtin adaptor in the child.
+	b, err := ParseIn[bool](child, "true")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	_, err = ParseIn[bool](child, "yes")
+	assert.Error(t, err)
+
+	// The parent is not affected.
+	b, err = ParseIn[bool](base, "yes")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	// Adapt removes the mask.
+	child.Adapt(typ, adaptor)
+	_, owner = child.Lookup(typ)
+	assert.Same(t, child, owner)
+}
+
+func TestNamespace_UndoAdapt_MaskExactTypeOnly(t *testing.T) {
+	base := NewNamespace()
+	base.AdaptKind(reflect.Bool, func(v any) (StringCodec, error) {
+		return (*YesNo)(v.(*bool)), nil
+	})
+	base.Adapt(ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*internal.Bool)(b), nil
+	}))
+
+	// The kind adaptor of the parent still applies to the masked type.
+	child := base.Derive()
+	child.UndoAdapt(typeOf[bool]())
+	b, err := ParseIn[bool](child, "yes")
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+// Enum is implemented by the types which can be converted to/from names.
+type Enum interface {
+	Name() string
+	ParseName(string) error
+}
+
+type Color int
+
+func (c Color) Name() string {
+	return [...]string{"red", "green", "blue"}[c]
+}
+
+func (c *Color) ParseName(s string) error {
+	for i, name := range [...]string{"red", "green", "blue"} {
+		if name == s {
+			*c = Color(i)
+			return nil
+		}
+	}
+	return errors.New("unknown color")
+}
+
+type enumCodec struct{ Enum }
+
+func (e enumCodec) ToString() (string, error) { return e.Name(), nil }
+func (e enumCodec) FromString(s string) error { return e.ParseName(s) }
+
+func enumAdaptor(v any) (StringCodec, error) {
+	return enumCodec{v.(Enum)}, nil
+}
+
+func TestNamespace_AdaptInterface(t *testing.T) {
+	ns := NewNamespace()
+	ns.AdaptInterface(typeOf[Enum](), enumAdaptor)
+
+	c, err := ParseIn[Color](ns, "green")
+	assert.NoError(t, err)
+	assert.Equal(t, Color(1), c)
+	s, err := FormatIn(ns, []Color{2, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, "blue,red", s)
+
+	// Exact adaptors take precedence.
+	typ, adaptor := ToAnyAdaptor(func(c *Color) (StringCodec, error) {
+		return (*internal.Int)(c), nil
+	})
+	ns.Adapt(typ, adaptor)
+	s, err = FormatIn(ns, Color(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", s)
+	ns.UndoAdapt(typ)
+
+	// Inherited by the child namespaces.
+	child := ns.Derive()
+	s, err = FormatIn(child, Color(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", s)
+
+	ns.UndoAdaptInterface(typeOf[Enum]())
+	ns.UndoAdaptInterface(typeOf[Enum]())
+	s, err = FormatIn(child, Color(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", s)
+}
+
+func TestNamespace_AdaptInterface_Order(t *testing.T) {
+	type Namer interface{ Name() string }
+	namerAdaptor := func(v any) (StringCodec, error) {
+		return enumCodec{v.(Enum)}, errors.New("namer")
+	}
+
+	ns := NewNamespace()
+	ns.AdaptInterface(typeOf[Namer](), namerAdaptor)
+	ns.AdaptInterface(typeOf[Enum](), enumAdaptor)
+	_, err := FormatIn(ns, Color(0))
+	assert.ErrorContains(t, err, "namer", "the first registered one wins")
+
+	// Replacing keeps the position.
+	ns.AdaptInterface(typeOf[Namer](), func(v any) (StringCodec, error) {
+		return nil, errors.New("namer2")
+	})
+	_, err = FormatIn(ns, Color(0))
+	assert.ErrorContains(t, err, "namer2")
+
+	// The child's interface adaptors win over the parent's.
+	child := ns.Derive()
+	child.AdaptInterface(typeOf[Enum](), enumAdaptor)
+	s, err := FormatIn(child, Color(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "red", s)
+
+	assert.Panics(t, func() {
+		ns.AdaptInterface(typeOf[int](), enumAdaptor)
+	})
+}
+
+// fixedFloat is a StringCodec of any float kinds with a fixed precision.
+type fixedFloat struct {
+	rv   reflect.Value
+	prec int
+}
+
+func (f fixedFloat) ToString() (string, error) {
+	return strconv.FormatFloat(f.rv.Float(), 'f', f.prec, f.rv.Type().Bits()), nil
+}
+
+func (f fixedFloat) FromString(s string) error {
+	v, err := strconv.ParseFloat(s, f.rv.Type().Bits())
+	if err != nil {
+		return err
+	}
+	f.rv.SetFloat(v)
+	return nil
+}
+
+func fixedFloatAdaptor(v any) (StringCodec, error) {
+	return fixedFloat{reflect.ValueOf(v).Elem(), 2}, nil
+}
+
+func TestNamespace_AdaptKind(t *testing.T) {
+	type Celsius float64
+
+	ns := NewNamespace()
+	ns.AdaptKind(reflect.Float64, fixedFloatAdaptor)
+
+	s, err := FormatIn(ns, 3.14159)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14", s)
+	s, err = FormatIn(ns, []Celsius{36.6, 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "36.60,100.00", s)
+	s, err = FormatIn(ns, float32(3.14159))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159", s, "other kinds are not affected")
+
+	// Interface adaptors take precedence.
+	ns.AdaptKind(reflect.Int, fixedFloatAdaptor)
+	ns.AdaptInterface(typeOf[Enum](), enumAdaptor)
+	s, err = FormatIn(ns, Color(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "green", s)
+	ns.UndoAdaptKind(reflect.Int)
+
+	// Inherited by the child namespaces.
+	child := ns.Derive()
+	s, err = FormatIn(child, Celsius(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.00", s)
+
+	ns.UndoAdaptKind(reflect.Float64)
+	s, err = FormatIn(child, Celsius(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", s)
+}
+
+func TestNamespace_AdaptFunc(t *testing.T) {
+	isColorSlice := func(typ reflect.Type) bool {
+		return typ.Kind() == reflect.Slice && typ.Elem() == typeOf[Color]()
+	}
+	colorSliceAdaptor := func(v any) (StringCodec, error) {
+		return formatterFunc(func() (string, error) {
+			return strconv.Itoa(len(*v.(*[]Color))) + " colors", nil
+		}), nil
+	}
+
+	ns := NewNamespace()
+	ns.AdaptFunc(isColorSlice, colorSliceAdaptor)
+	ns.AdaptFunc(func(reflect.Type) bool { return true }, func(v any) (StringCodec, error) {
+		return nil, errors.New("catch-all")
+	})
+
+	s, err := FormatIn(ns, []Color{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "2 colors", s)
+
+	_, err = FormatIn(ns, 1)
+	assert.ErrorContains(t, err, "catch-all", "predicate adaptors take precedence over builtins")
+
+	// Kind adaptors take precedence.
+	ns.AdaptKind(reflect.Slice, func(v any) (StringCodec, error) {
+		return nil, errors.New("kind")
+	})
+	_, err = FormatIn(ns, []Color{1, 2})
+	assert.ErrorContains(t, err, "kind")
+}
+
+func TestNamespace_UndoAdaptFunc(t *testing.T) {
+	isColor := func(typ reflect.Type) bool { return typ == typeOf[Color]() }
+	adaptor := func(v any) (StringCodec, error) {
+		return formatterFunc(func() (string, error) { return "color", nil }), nil
+	}
+
+	parent := NewNamespace()
+	child := parent.Derive()
+	h1 := child.AdaptFunc(isColor, adaptor)
+	h2 := child.AdaptFunc(isColor, func(v any) (StringCodec, error) {
+		return formatterFunc(func() (string, error) { return "second", nil }), nil
+	})
+	assert.NotEqual(t, h1, h2)
+	assert.Equal(t, "color", must(FormatIn(child, Color(1), NoHybrid())))
+
+	// The handles of the other namespaces are ignored.
+	parent.UndoAdaptFunc(h1)
+	assert.Equal(t, "color", must(FormatIn(child, Color(1), NoHybrid())))
+
+	child.UndoAdaptFunc(h1)
+	assert.Equal(t, "second", must(FormatIn(child, Color(1), NoHybrid())))
+	child.UndoAdaptFunc(h1)
+	child.UndoAdaptFunc(h2)
+	assert.Equal(t, "1", must(FormatIn(child, Color(1), NoHybrid())))
+}
+
+// formatterFunc is a StringCodec which only supports ToString.
+type formatterFunc func() (string, error)
+
+func (f formatterFunc) ToString() (string, error) { return f() }
+func (f formatterFunc) FromString(string) error   { return ErrNotStringUnmarshaler }
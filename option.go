@@ -1,5 +1,12 @@
 package strconvx
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
 // Option adjusts the hybrid behaviour when creating a `StringCodec` instance with `New()` method.
 type Option func(o *options)
 
@@ -26,12 +33,328 @@ func CompleteHybrid() Option {
 	}
 }
 
+// DurationUnit makes the builtin time.Duration codec also accept plain
+// integers, which are interpreted in the given unit.
+//
+// Example:
+//
+//	// "30" is parsed as 30s, "1h30m" is still accepted
+//	New(&timeout, DurationUnit(time.Second))
+func DurationUnit(unit time.Duration) Option {
+	return func(o *options) {
+		o.DurationUnit = unit
+	}
+}
+
+// TimeLayouts sets the layouts accepted by the builtin time.Time codec, which
+// are tried in order before the unix timestamps, see UnixPrecision. The
+// default layouts are time.RFC3339Nano and "2006-01-02".
+//
+// Example:
+//
+//	New(&t, TimeLayouts(time.RFC1123, time.DateTime))
+func TimeLayouts(layouts ...string) Option {
+	return func(o *options) {
+		o.TimeLayouts = layouts
+	}
+}
+
+// TimeFormat sets the layout used by the builtin time.Time codec to format the
+// values. The default layout is time.RFC3339Nano.
+func TimeFormat(layout string) Option {
+	return func(o *options) {
+		o.TimeFormat = layout
+	}
+}
+
+// TimeLocation sets the location used by the builtin time.Time codec to parse
+// the input without time zone information, and to format the values. The
+// default location is UTC.
+func TimeLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.TimeLocation = loc
+	}
+}
+
+// UnixPrecision sets the unit of the unix timestamps accepted by the builtin
+// time.Time codec, one of time.Second (the default), time.Millisecond,
+// time.Microsecond and time.Nanosecond. New returns an error on the other
+// units. See also AutoUnixPrecision.
+//
+// Example:
+//
+//	// "1618974933284" is 2021-04-21T03:15:33.284Z
+//	New(&t, UnixPrecision(time.Millisecond))
+func UnixPrecision(unit time.Duration) Option {
+	return func(o *options) {
+		o.UnixPrecision = unit
+	}
+}
+
+// AutoUnixPrecision makes the builtin time.Time codec detect the unit of the
+// unix timestamps by their magnitude, i.e. both "1618974933" (seconds) and
+// "1618974933284" (milliseconds) are accepted. Timestamps in seconds are
+// recognized up to the year 5138.
+func AutoUnixPrecision() Option {
+	return func(o *options) {
+		o.UnixPrecision = internal.UnixAuto
+	}
+}
+
+// IntBase sets the base of the integers accepted by the builtin codecs of all
+// the integer types, i.e. int, int8, ..., uint64. The default base is 10.
+// Base 0 means the base is implied by the prefix, i.e. "0b", "0o" (or "0") and
+// "0x", and underscores are permitted as digit separators, see
+// strconv.ParseInt.
+//
+// Example:
+//
+//	// "0x1F", "0o755", "0b1010", "1_000_000" are all accepted
+//	New(&n, IntBase(0))
+func IntBase(base int) Option {
+	return func(o *options) {
+		o.IntBase = base
+	}
+}
+
+// IntFormatBase sets the base of the integers formatted by the builtin codecs
+// of all the integer types. The default base is 10. In base 2, 8 and 16, the
+// output is prefixed with "0b", "0o" and "0x" respectively, which is also
+// accepted on input in the default IntBase, so that the output can be parsed
+// back by the same codec.
+//
+// Example:
+//
+//	// 31 => "0x1f"
+//	New(&flags, IntFormatBase(16))
+func IntFormatBase(base int) Option {
+	return func(o *options) {
+		o.IntFormatBase = base
+	}
+}
+
+// ClampOverflow makes the builtin codecs of all the integer types saturate
+// the values out of range to the min or max value of the type, instead of
+// returning an error, e.g. "300" is parsed as 127 for int8, and "-1" is
+// parsed as 0 for uint.
+func ClampOverflow() Option {
+	return func(o *options) {
+		o.Opt(optionClampOverflow)
+	}
+}
+
+// FloatFormat sets the format byte and the precision used by the builtin
+// codecs of float32 and float64 to format the values, see strconv.FormatFloat.
+// The default is 'f' with precision -1, i.e. the shortest representation
+// without an exponent that parses back to the same value.
+//
+// Example:
+//
+//	// 3.14159 => "3.14"
+//	New(&price, FloatFormat('f', 2))
+//	// 1234.5 => "1.2345e+03"
+//	New(&x, FloatFormat('e', -1))
+func FloatFormat(format byte, prec int) Option {
+	return func(o *options) {
+		o.FloatFormat = format
+		o.FloatPrecision = prec
+	}
+}
+
+// RejectNonFinite makes the builtin codecs of float32 and float64 return an
+// error wrapping ErrNonFinite on "NaN", "Inf", "+Inf" and "-Inf", which are
+// accepted by default.
+func RejectNonFinite() Option {
+	return func(o *options) {
+		o.Opt(optionRejectNonFinite)
+	}
+}
+
+// BoolWords makes the builtin bool codec also accept the given word sets, in
+// addition to the ones accepted by strconv.ParseBool, e.g. "1", "t", "true".
+// The words are matched case-insensitively, see BoolCaseSensitive.
+//
+// Example:
+//
+//	// "yes", "Off", "N" are all accepted
+//	ns.SetOptions(BoolWords(BoolYesNo, BoolOnOff, BoolYN))
+func BoolWords(sets ...BoolWordSet) Option {
+	return func(o *options) {
+		o.BoolWords = sets
+	}
+}
+
+// BoolOutput sets the words used by the builtin bool codec to format the
+// values, which are "true" and "false" by default. The output words are also
+// accepted when parsing, so that the values can be parsed back.
+//
+// Example:
+//
+//	// true => "yes", false => "no"
+//	New(&enabled, BoolOutput("yes", "no"))
+func BoolOutput(trueWord, falseWord string) Option {
+	return func(o *options) {
+		o.BoolOutput = BoolWordSet{True: trueWord, False: falseWord}
+	}
+}
+
+// BoolCaseSensitive makes the builtin bool codec match the words of BoolWords
+// and BoolOutput exactly, e.g. "YES" is rejected with BoolYesNo. It doesn't
+// affect the words accepted by strconv.ParseBool.
+func BoolCaseSensitive() Option {
+	return func(o *options) {
+		o.Opt(optionBoolCaseSensitive)
+	}
+}
+
+// BytesEncoding sets the encoding of []byte values, which is
+// base64.StdEncoding by default. It also makes byte arrays, e.g. [32]byte,
+// converted as a whole with the encoding, instead of element by element. The
+// length of the decoded bytes must match the length of the array.
+//
+// Example:
+//
+//	// "deadbeef" <=> []byte{0xde, 0xad, 0xbe, 0xef}
+//	New(&hash, BytesEncoding(HexEncoding))
+//	New(&token, BytesEncoding(base64.RawURLEncoding))
+//	New(&id, BytesEncoding(base32.StdEncoding))
+func BytesEncoding(enc BinaryEncoding) Option {
+	return func(o *options) {
+		o.BytesEncoding = enc
+	}
+}
+
+// LenientBytes makes the decoding of []byte and [N]byte values ignore the
+// whitespaces, and accept the base64 and base32 input with or without
+// padding, see BytesEncoding.
+func LenientBytes() Option {
+	return func(o *options) {
+		o.Opt(optionLenientBytes)
+	}
+}
+
+// Separator sets the separator of the elements when converting slices and
+// arrays, and the separator of the entries when converting maps. The default
+// separator is a comma.
+//
+// Example:
+//
+//	// "1;2;3" <=> []int{1, 2, 3}
+//	New(&ids, Separator(";"))
+func Separator(sep string) Option {
+	return func(o *options) {
+		o.Separator = sep
+	}
+}
+
+// Quote sets the quote character used to enclose the elements that contain
+// the separators when converting slices, arrays and maps. Within a quoted
+// element, a pair of quote characters stands for one quote character, like in
+// CSV. The default quote character is a double quote. Use Quote(0) to disable
+// quoting.
+//
+// Example:
+//
+//	// `a,b,"c,d"` <=> []string{"a", "b", "c,d"}
+//	New(&tags)
+func Quote(q rune) Option {
+	return func(o *options) {
+		o.Quote = q
+	}
+}
+
+// KeyValueSeparator sets the separator between the key and the value of an
+// entry when converting maps. The default separator is an equal sign.
+//
+// Example:
+//
+//	// "1:true;2:false" <=> map[int]bool{1: true, 2: false}
+//	New(&m, Separator(";"), KeyValueSeparator(":"))
+func KeyValueSeparator(sep string) Option {
+	return func(o *options) {
+		o.KeyValueSeparator = sep
+	}
+}
+
+// Null sets the string representation of nil pointers, which is used when
+// New is given a pointer to a pointer, e.g. **int. The default null
+// representation is an empty string. FromString with the null representation
+// sets the pointer to nil.
+//
+// Example:
+//
+//	var p *int
+//	New(&p, Null("null")) // "null" <=> nil, "1" <=> pointer to 1
+func Null(s string) Option {
+	return func(o *options) {
+		o.Null = s
+	}
+}
+
 type options struct {
-	Value uint8
+	Value             uint8
+	DurationUnit      time.Duration
+	TimeLayouts       []string
+	TimeFormat        string
+	TimeLocation      *time.Location
+	UnixPrecision     time.Duration
+	BytesEncoding     BinaryEncoding
+	BoolWords         []BoolWordSet
+	BoolOutput        BoolWordSet
+	IntBase           int
+	IntFormatBase     int
+	FloatFormat       byte
+	FloatPrecision    int
+	Separator         string
+	KeyValueSeparator string
+	Quote             rune
+	Null              string
 }
 
 func defaultOptions() *options {
-	return &options{}
+	return &options{
+		Separator:         ",",
+		KeyValueSeparator: "=",
+		Quote:             '"',
+		IntBase:           10,
+		IntFormatBase:     10,
+		FloatFormat:       'f',
+		FloatPrecision:    -1,
+	}
+}
+
+func (o *options) validateUnixPrecision() error {
+	switch o.UnixPrecision {
+	case 0, internal.UnixAuto, time.Second, time.Millisecond, time.Microsecond, time.Nanosecond:
+		return nil
+	}
+	return fmt.Errorf("invalid unix precision: %v", o.UnixPrecision)
+}
+
+func (o *options) hasIntOptions() bool {
+	return o.IntBase != 10 || o.IntFormatBase != 10 || o.Has(optionClampOverflow)
+}
+
+func (o *options) validateIntBases() error {
+	if o.IntBase != 0 && (o.IntBase < 2 || o.IntBase > 36) {
+		return fmt.Errorf("invalid integer base: %d", o.IntBase)
+	}
+	if o.IntFormatBase < 2 || o.IntFormatBase > 36 {
+		return fmt.Errorf("invalid integer format base: %d", o.IntFormatBase)
+	}
+	return nil
+}
+
+func (o *options) hasFloatOptions() bool {
+	return o.FloatFormat != 'f' || o.FloatPrecision != -1 || o.Has(optionRejectNonFinite)
+}
+
+func (o *options) validateFloatFormat() error {
+	switch o.FloatFormat {
+	case 'b', 'e', 'E', 'f', 'g', 'G', 'x', 'X':
+		return nil
+	}
+	return fmt.Errorf("invalid float format: %q", o.FloatFormat)
 }
 
 func (o *options) Opt(v option) {
@@ -47,4 +370,8 @@ type option int
 const (
 	optionNoHybrid option = 1 << iota
 	optionCompleteHybrid
+	optionLenientBytes
+	optionClampOverflow
+	optionRejectNonFinite
+	optionBoolCaseSensitive
 )
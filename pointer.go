@@ -0,0 +1,57 @@
+package strconvx
+
+import "reflect"
+
+// pointerCodec is a StringCodec of pointers, which is created when New is
+// given a pointer to a pointer, e.g. **int. A nil pointer is converted to the
+// null representation (see Null) and vice versa. Otherwise, the pointed value
+// is converted with the StringCodec of its type, which is created by the same
+// Namespace. The pointer is allocated on FromString if it's nil.
+type pointerCodec struct {
+	ns   *Namespace
+	rv   reflect.Value // the pointer
+	opts *options
+}
+
+func (c *Namespace) createPointerStringCodec(rv reflect.Value, opts *options) (StringCodec, error) {
+	return &pointerCodec{ns: c, rv: rv.Elem(), opts: opts}, nil
+}
+
+// IsBoolFlag reports whether the pointed values are converted by a boolean
+// flag codec, see FlagValue.
+func (pc *pointerCodec) IsBoolFlag() bool {
+	codec, err := pc.ns.createStringCodec(reflect.New(pc.rv.Type().Elem()), pc.opts)
+	return err == nil && isBoolFlag(codec)
+}
+
+func (pc *pointerCodec) ToString() (string, error) {
+	if pc.rv.IsNil() {
+		return pc.opts.Null, nil
+	}
+	codec, err := pc.ns.createStringCodec(pc.rv, pc.opts)
+	if err != nil {
+		return "", err
+	}
+	return codec.ToString()
+}
+
+func (pc *pointerCodec) FromString(s string) error {
+	if s == pc.opts.Null {
+		pc.rv.Set(reflect.Zero(pc.rv.Type()))
+		return nil
+	}
+
+	ptr := pc.rv
+	if ptr.IsNil() {
+		ptr = reflect.New(pc.rv.Type().Elem())
+	}
+	codec, err := pc.ns.createStringCodec(ptr, pc.opts)
+	if err != nil {
+		return err
+	}
+	if err := codec.FromString(s); err != nil {
+		return err
+	}
+	pc.rv.Set(ptr)
+	return nil
+}
@@ -0,0 +1,111 @@
+package strconvx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointerCodec(t *testing.T) {
+	var p *int
+	sv, err := New(&p)
+	assert.NoError(t, err)
+
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	assert.Error(t, sv.FromString("hello"))
+	assert.Nil(t, p, "should not allocate on error")
+
+	assert.NoError(t, sv.FromString("10"))
+	assert.NotNil(t, p)
+	assert.Equal(t, 10, *p)
+	got, err = sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "10", got)
+
+	// Update the pointed value in place.
+	q := p
+	assert.NoError(t, sv.FromString("20"))
+	assert.Same(t, q, p)
+	assert.Equal(t, 20, *q)
+
+	assert.NoError(t, sv.FromString(""))
+	assert.Nil(t, p)
+}
+
+func TestPointerCodec_Null(t *testing.T) {
+	var p *string
+	sv, err := NewNamespace().New(&p, Null("null"))
+	assert.NoError(t, err)
+
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", got)
+
+	assert.NoError(t, sv.FromString(""))
+	assert.NotNil(t, p)
+	assert.Equal(t, "", *p)
+
+	assert.NoError(t, sv.FromString("null"))
+	assert.Nil(t, p)
+}
+
+func TestPointerCodec_PointerChain(t *testing.T) {
+	var p **YesNo
+	sv, err := New(&p)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("yes"))
+	assert.NotNil(t, p)
+	assert.NotNil(t, *p)
+	assert.Equal(t, YesNo(true), **p)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", got)
+
+	*p = nil
+	got, err = sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+}
+
+func TestPointerCodec_SliceElements(t *testing.T) {
+	var ids []*int
+	sv, err := New(&ids)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString(`1,"",3`))
+	assert.Len(t, ids, 3)
+	assert.Equal(t, 1, *ids[0])
+	assert.Nil(t, ids[1])
+	assert.Equal(t, 3, *ids[2])
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `1,"",3`, got)
+}
+
+func TestPointerCodec_ErrUnsupportedType(t *testing.T) {
+	var p *StructNotStringConvertable
+	sv, err := New(&p)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sv)
+}
+
+func TestPointerCodec_SelfReferential(t *testing.T) {
+	type P *P
+	var p P
+	sv, err := New(&p)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sv)
+
+	// Pointers to self-referential slices are fine.
+	type List []*List
+	var l List
+	sv, err = New(&l)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString(","))
+	assert.Len(t, l, 2)
+	assert.Equal(t, `"",""`, must(sv.ToString()))
+}
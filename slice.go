@@ -0,0 +1,77 @@
+package strconvx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
+// sliceCodec is a StringCodec of slices and arrays. It converts the elements
+// one by one with the StringCodec of the element type, which is created by
+// the same Namespace, and joins them with a separator. The elements which
+// contain the separator are quoted.
+type sliceCodec struct {
+	ns   *Namespace
+	rv   reflect.Value // the slice or array
+	opts *options
+}
+
+func (c *Namespace) createSliceStringCodec(rv reflect.Value, opts *options) (StringCodec, error) {
+	return &sliceCodec{ns: c, rv: rv.Elem(), opts: opts}, nil
+}
+
+func (sc *sliceCodec) ToString() (string, error) {
+	elems := make([]string, sc.rv.Len())
+	for i := range elems {
+		s, err := sc.elemToString(sc.rv.Index(i))
+		if err != nil {
+			return "", internal.ToStringError(sc.rv.Type(), fmt.Errorf("element %d: %w", i, err))
+		}
+		elems[i] = internal.Quote(s, sc.opts.Quote, sc.opts.Separator)
+	}
+	return strings.Join(elems, sc.opts.Separator), nil
+}
+
+func (sc *sliceCodec) FromString(s string) error {
+	elems, err := internal.Split(s, sc.opts.Separator, sc.opts.Quote)
+	if err != nil {
+		return internal.FromStringError(sc.rv.Type(), s, err)
+	}
+
+	var newValue reflect.Value
+	if sc.rv.Kind() == reflect.Array {
+		if len(elems) != sc.rv.Len() {
+			err := fmt.Errorf("%w: expected %d elements, got %d", ErrLengthMismatch, sc.rv.Len(), len(elems))
+			return internal.FromStringError(sc.rv.Type(), s, err)
+		}
+		newValue = reflect.New(sc.rv.Type()).Elem()
+	} else {
+		newValue = reflect.MakeSlice(sc.rv.Type(), len(elems), len(elems))
+	}
+
+	for i, elem := range elems {
+		if err := sc.elemFromString(newValue.Index(i), internal.Unquote(elem, sc.opts.Quote)); err != nil {
+			return internal.FromStringError(sc.rv.Type(), s, internal.ElementError(i, err))
+		}
+	}
+	sc.rv.Set(newValue)
+	return nil
+}
+
+func (sc *sliceCodec) elemToString(elem reflect.Value) (string, error) {
+	codec, err := sc.ns.createStringCodec(elem.Addr(), sc.opts)
+	if err != nil {
+		return "", err
+	}
+	return codec.ToString()
+}
+
+func (sc *sliceCodec) elemFromString(elem reflect.Value, s string) error {
+	codec, err := sc.ns.createStringCodec(elem.Addr(), sc.opts)
+	if err != nil {
+		return err
+	}
+	return codec.FromString(s)
+}
@@ -0,0 +1,143 @@
+package strconvx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceCodec_Ints(t *testing.T) {
+	var ids []int
+	sv, err := New(&ids)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("1,2,3"))
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2,3", got)
+
+	assert.NoError(t, sv.FromString(""))
+	assert.Equal(t, []int{}, ids)
+	got, err = sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	ids = []int{1}
+	assert.ErrorContains(t, sv.FromString("1,hello,3"), "element 1")
+	assert.Equal(t, []int{1}, ids, "should not change on error")
+}
+
+func TestSliceCodec_Quote(t *testing.T) {
+	var tags []string
+	sv, err := New(&tags)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString(`a,b,"c,d"`))
+	assert.Equal(t, []string{"a", "b", "c,d"}, tags)
+
+	assert.NoError(t, sv.FromString(`"say ""hi""",,x"y`))
+	assert.Equal(t, []string{`say "hi"`, "", `x"y`}, tags)
+
+	tags = []string{"a", "c,d", `x"y`, ""}
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `a,"c,d","x""y",""`, got)
+	assert.NoError(t, sv.FromString(got))
+	assert.Equal(t, []string{"a", "c,d", `x"y`, ""}, tags)
+
+	assert.ErrorContains(t, sv.FromString(`a,"b`), "unterminated quote")
+	assert.ErrorContains(t, sv.FromString(`a,"b"c`), "unexpected characters after the closing quote")
+}
+
+func TestSliceCodec_Options(t *testing.T) {
+	ns := NewNamespace()
+
+	var tags []string
+	sv, err := ns.New(&tags, Separator("|"), Quote('\''))
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString(`a|'b|c'|'it''s'`))
+	assert.Equal(t, []string{"a", "b|c", "it's"}, tags)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `a|'b|c'|'it''s'`, got)
+
+	sv, err = ns.New(&tags, Quote(0))
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString(`"a,b"`))
+	assert.Equal(t, []string{`"a`, `b"`}, tags)
+}
+
+func TestSliceCodec_Nested(t *testing.T) {
+	var matrix [][]int
+	sv, err := New(&matrix)
+	assert.NoError(t, err)
+
+	matrix = [][]int{{1, 2}, {3}, {}}
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `"1,2",3,""`, got)
+
+	assert.NoError(t, sv.FromString(`4,"5,6"`))
+	assert.Equal(t, [][]int{{4}, {5, 6}}, matrix)
+}
+
+func TestSliceCodec_Array(t *testing.T) {
+	var point [3]float64
+	sv, err := New(&point)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("1.5,2,-3"))
+	assert.Equal(t, [3]float64{1.5, 2, -3}, point)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5,2,-3", got)
+
+	assert.ErrorIs(t, sv.FromString("1,2"), ErrLengthMismatch)
+	assert.ErrorIs(t, sv.FromString("1,2,3,4"), ErrLengthMismatch)
+	assert.Equal(t, [3]float64{1.5, 2, -3}, point)
+}
+
+func TestSliceCodec_NamedAndHybridElements(t *testing.T) {
+	type Durations []time.Duration
+	var durations Durations
+	sv, err := New(&durations)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("1s,1h30m"))
+	assert.Equal(t, Durations{time.Second, 90 * time.Minute}, durations)
+
+	var answers []YesNo
+	sv, err = New(&answers)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("yes,no"))
+	assert.Equal(t, []YesNo{true, false}, answers)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "yes,no", got)
+
+	answers = []YesNo{true}
+	assert.ErrorContains(t, sv.FromString("yes,maybe"), "invalid value")
+}
+
+func TestSliceCodec_ErrUnsupportedType(t *testing.T) {
+	var s []StructNotStringConvertable
+	sv, err := New(&s)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sv)
+}
+
+func TestSliceCodec_SelfReferential(t *testing.T) {
+	type List []List
+	l := List{List{}, List{}}
+	sv, err := New(&l)
+	assert.NoError(t, err)
+	assert.Equal(t, `"",""`, must(sv.ToString()))
+	assert.NoError(t, sv.FromString(",,"))
+	assert.Len(t, l, 3)
+
+	type Bad []struct{ Bad Bad }
+	var b Bad
+	_, err = New(&b)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
@@ -0,0 +1,68 @@
+package strconvx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// SQLCodec adapts a StringCodec to sql.Scanner and driver.Valuer, so that a
+// value can be stored in a TEXT column in exactly the same format as its
+// ToString output. Scan accepts string and []byte, and calls FromString.
+// Value calls ToString.
+//
+// When the StringCodec is created from a pointer to a pointer, e.g. **int,
+// SQL NULL is mapped to the nil pointer and vice versa.
+type SQLCodec struct {
+	StringCodec
+}
+
+// SQLValue creates a SQLCodec from v with the StringCodec created by the given
+// namespace with the given options, see Namespace.New. A nil namespace means
+// the default namespace.
+//
+// Example:
+//
+//	var status Status
+//	sv, err := strconvx.SQLValue(ns, &status)
+//	row := db.QueryRow("SELECT status FROM users WHERE id = ?", 1)
+//	err = row.Scan(sv)
+//	_, err = db.Exec("UPDATE users SET status = ? WHERE id = ?", sv, 1)
+func SQLValue(ns *Namespace, v any, opts ...Option) (SQLCodec, error) {
+	if ns == nil {
+		ns = defaultNS
+	}
+	codec, err := ns.New(v, opts...)
+	if err != nil {
+		return SQLCodec{}, err
+	}
+	return SQLCodec{codec}, nil
+}
+
+func (sc SQLCodec) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return sc.FromString(s)
+	case []byte:
+		return sc.FromString(string(s))
+	case nil:
+		if pc, ok := sc.StringCodec.(*pointerCodec); ok {
+			return pc.FromString(pc.opts.Null)
+		}
+		return fmt.Errorf("%w: cannot scan NULL into a non-pointer value", ErrTypeMismatch)
+	default:
+		return fmt.Errorf("%w: cannot scan %T, only string and []byte are supported", ErrTypeMismatch, src)
+	}
+}
+
+func (sc SQLCodec) Value() (driver.Value, error) {
+	if pc, ok := sc.StringCodec.(*pointerCodec); ok && pc.rv.IsNil() {
+		return nil, nil
+	}
+	return sc.ToString()
+}
+
+var (
+	_ sql.Scanner   = SQLCodec{}
+	_ driver.Valuer = SQLCodec{}
+)
@@ -0,0 +1,166 @@
+package strconvx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver is a database/sql driver which has only one table with one
+// column. "INSERT" appends a row, and "SELECT" returns all the rows.
+type fakeDriver struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+type fakeConn struct{ d *fakeDriver }
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+type fakeRows struct {
+	rows []driver.Value
+	i    int
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.d, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	switch s.query {
+	case "INSERT":
+		s.d.rows = append(s.d.rows, args[0])
+	case "DELETE":
+		s.d.rows = nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{rows: append([]driver.Value(nil), s.d.rows...)}, nil
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.i]
+	r.i++
+	return nil
+}
+
+var fakeDB = &fakeDriver{}
+
+func init() {
+	sql.Register("strconvx-fake", fakeDB)
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("strconvx-fake", "")
+	assert.NoError(t, err)
+	_, err = db.Exec("DELETE")
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLCodec(t *testing.T) {
+	db := openFakeDB(t)
+	ns := yesNoNamespace()
+
+	for _, b := range []bool{true, false} {
+		sv, err := SQLValue(ns, &b)
+		assert.NoError(t, err)
+		_, err = db.Exec("INSERT", sv)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []driver.Value{"yes", "no"}, fakeDB.rows)
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+	var got []bool
+	for rows.Next() {
+		var b bool
+		sv, err := SQLValue(ns, &b)
+		assert.NoError(t, err)
+		assert.NoError(t, rows.Scan(sv))
+		got = append(got, b)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []bool{true, false}, got)
+}
+
+func TestSQLCodec_DefaultNamespace(t *testing.T) {
+	db := openFakeDB(t)
+
+	timeout := 90 * time.Second
+	sv, err := SQLValue(nil, &timeout)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", sv)
+	assert.NoError(t, err)
+
+	var got time.Duration
+	sv, err = SQLValue(nil, &got)
+	assert.NoError(t, err)
+	assert.NoError(t, db.QueryRow("SELECT").Scan(sv))
+	assert.Equal(t, 90*time.Second, got)
+}
+
+func TestSQLCodec_Null(t *testing.T) {
+	db := openFakeDB(t)
+
+	var p *int
+	sv, err := SQLValue(nil, &p)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", sv)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{nil}, fakeDB.rows)
+
+	p = pointerize(1)
+	assert.NoError(t, db.QueryRow("SELECT").Scan(sv))
+	assert.Nil(t, p)
+
+	var i int
+	sv, err = SQLValue(nil, &i)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, sv.Scan(nil), ErrTypeMismatch)
+}
+
+func TestSQLCodec_Scan(t *testing.T) {
+	var i int
+	sv, err := SQLValue(nil, &i)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.Scan("1"))
+	assert.Equal(t, 1, i)
+	assert.NoError(t, sv.Scan([]byte("2")))
+	assert.Equal(t, 2, i)
+	assert.ErrorIs(t, sv.Scan(int64(3)), ErrTypeMismatch)
+
+	v, err := sv.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", v)
+
+	_, err = SQLValue(nil, &StructNotStringConvertable{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
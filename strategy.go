@@ -0,0 +1,217 @@
+package strconvx
+
+import (
+	"maps"
+	"reflect"
+)
+
+// strategy creates a StringCodec from a non-nil pointer of a specific type.
+// The strategy of a type is resolved once and cached in the namespace, so
+// that the repeated New calls on the same type don't have to look up the
+// adaptors and check the interfaces again, see Namespace.strategyOf.
+type strategy func(rv reflect.Value, opts *options) (StringCodec, error)
+
+// strategyOptions are the options which affect the resolution of a strategy.
+// The other options only affect the created StringCodecs.
+const strategyOptions = uint8(optionNoHybrid | optionCompleteHybrid)
+
+// strategyCache maps the pointer types to their strategies, indexed by the
+// strategy options. It's replaced as a whole when a strategy is added, so
+// that New looks up the cached strategies without locking the namespace.
+type strategyCache map[reflect.Type]*[strategyOptions + 1]cachedStrategy
+
+// lookup returns the cached strategy of the given type and options, c can be
+// nil.
+func (c *strategyCache) lookup(typ reflect.Type, options uint8) (cachedStrategy, bool) {
+	if c == nil {
+		return cachedStrategy{}, false
+	}
+	if cached, ok := (*c)[typ]; ok {
+		return cached[options], cached[options].strategy != nil || cached[options].err != nil
+	}
+	return cachedStrategy{}, false
+}
+
+type cachedStrategy struct {
+	strategy strategy
+	err      error
+	version  uint64 // the version of the namespace chain, see Namespace.chainVersion
+}
+
+// strategyOf returns the strategy for the given pointer type. The resolved
+// strategy is cached until an adaptor is registered or removed in the
+// namespace or any of its ancestors.
+func (c *Namespace) strategyOf(typ reflect.Type, opts *options) (strategy, error) {
+	return c.strategyIn(typ, opts, nil)
+}
+
+// resolving records the pointer types being resolved, which are the types
+// containing the type being resolved, e.g. *Tree while resolving the
+// elements of "type Tree map[string]Tree".
+type resolving map[reflect.Type]bool
+
+func (c *Namespace) strategyIn(typ reflect.Type, opts *options, stack resolving) (strategy, error) {
+	options := opts.Value & strategyOptions
+	version := c.chainVersion()
+
+	if cached, ok := c.strategies.Load().lookup(typ, options); ok && cached.version == version {
+		return cached.strategy, cached.err
+	}
+
+	// The strategy is resolved without the lock, if an adaptor gets registered
+	// meanwhile, the version changes and the cached one will be resolved again.
+	s, err := c.resolveStrategy(typ, opts, stack)
+	if len(stack) > 0 {
+		// The strategies of the contained types are cached when they are
+		// resolved on their own, since they may depend on the types in the
+		// stack, which are assumed to be supported, see checkSupported.
+		return s, err
+	}
+	c.mu.Lock()
+	cache := strategyCache{}
+	if old := c.strategies.Load(); old != nil {
+		cache = maps.Clone(*old)
+	}
+	cached := new([strategyOptions + 1]cachedStrategy)
+	if old, ok := cache[typ]; ok {
+		*cached = *old
+	}
+	cached[options] = cachedStrategy{s, err, version}
+	cache[typ] = cached
+	c.strategies.Store(&cache)
+	c.mu.Unlock()
+	return s, err
+}
+
+// chainVersion returns the version of the adaptors registered in c and its
+// ancestors. Each namespace increases its own version on every change of
+// its adaptors, so the sum changes whenever any of them changes.
+func (c *Namespace) chainVersion() uint64 {
+	var version uint64
+	for ns := c; ns != nil; ns = ns.parent {
+		version += ns.version.Load()
+	}
+	return version
+}
+
+// resolveStrategy resolves the strategy for the given pointer type, see New
+// for the order of the approaches.
+func (c *Namespace) resolveStrategy(typ reflect.Type, opts *options, stack resolving) (strategy, error) {
+	baseType := typ.Elem()
+
+	// Check if there is a custom adaptor for the base type, or an
+	// interface/kind/predicate adaptor which applies to it.
+	if adapt := c.lookupCustom(baseType); adapt != nil {
+		return adaptorStrategy(adapt), nil
+	}
+
+	// Check if there is a built-in adaptor for the base type.
+	if adapt, ok := builtinAdaptors[baseType]; ok {
+		return func(rv reflect.Value, opts *options) (StringCodec, error) {
+			return adapt(rv.Interface(), opts)
+		}, nil
+	}
+
+	// Try to create a hybrid StringCodec from the reflect.Value.
+	if !opts.Has(optionNoHybrid) {
+		if newHybrid := hybridOf(typ); newHybrid != nil {
+			if opts.Has(optionCompleteHybrid) {
+				// Validate a zero hybrid of the type, which has the same
+				// interfaces as the ones created from the values.
+				if err := newHybrid(reflect.New(baseType)).validateAsComplete(); err != nil {
+					return nil, err
+				}
+			}
+			return func(rv reflect.Value, _ *options) (StringCodec, error) {
+				return newHybrid(rv), nil
+			}, nil
+		}
+	}
+
+	// Fall back to the builtin adaptor of the underlying type.
+	if bt := underlyingBuiltinType(baseType); bt != nil {
+		adapt, btPtr := builtinAdaptors[bt], reflect.PointerTo(bt)
+		return func(rv reflect.Value, opts *options) (StringCodec, error) {
+			codec, err := adapt(rv.Convert(btPtr).Interface(), opts)
+			if err != nil {
+				return nil, err
+			}
+			return &namedCodec{codec, baseType}, nil
+		}, nil
+	}
+
+	// Convert pointers, slices, arrays and maps with the StringCodecs of the
+	// types they contain.
+	if stack == nil {
+		stack = make(resolving)
+	}
+	stack[typ] = true
+	defer delete(stack, typ)
+	switch baseType.Kind() {
+	case reflect.Pointer:
+		if pointerCycle(baseType) {
+			return nil, unsupportedType(baseType)
+		}
+		if err := c.checkSupported(baseType.Elem(), opts, stack); err != nil {
+			return nil, err
+		}
+		return c.createPointerStringCodec, nil
+	case reflect.Slice, reflect.Array:
+		if err := c.checkSupported(baseType.Elem(), opts, stack); err != nil {
+			return nil, err
+		}
+		if baseType.Kind() == reflect.Array && baseType.Elem() == byteType {
+			// Byte arrays are converted as a whole when BytesEncoding is set.
+			return func(rv reflect.Value, opts *options) (StringCodec, error) {
+				if opts.BytesEncoding != nil {
+					return createByteArrayStringCodec(rv, opts)
+				}
+				return c.createSliceStringCodec(rv, opts)
+			}, nil
+		}
+		return c.createSliceStringCodec, nil
+	case reflect.Map:
+		if err := c.checkSupported(baseType.Key(), opts, stack); err != nil {
+			return nil, err
+		}
+		if err := c.checkSupported(baseType.Elem(), opts, stack); err != nil {
+			return nil, err
+		}
+		return c.createMapStringCodec, nil
+	}
+
+	return nil, unsupportedType(baseType)
+}
+
+func adaptorStrategy(adapt AnyAdaptor) strategy {
+	return func(rv reflect.Value, _ *options) (StringCodec, error) {
+		return adapt(rv.Interface())
+	}
+}
+
+// checkSupported returns an error if the given type is not supported, i.e.
+// the types contained in pointers, slices, arrays and maps. The types being
+// resolved are assumed to be supported, so that the self-referential types,
+// e.g. "type Tree map[string]Tree", are resolved without an endless recursion.
+// Their elements are converted by the StringCodecs created on demand.
+func (c *Namespace) checkSupported(typ reflect.Type, opts *options, stack resolving) error {
+	ptrType := reflect.PointerTo(typ)
+	if stack[ptrType] {
+		return nil
+	}
+	_, err := c.strategyIn(ptrType, opts, stack)
+	return err
+}
+
+// pointerCycle reports whether typ is a pointer type which never points to a
+// non-pointer value, e.g. "type P *P".
+func pointerCycle(typ reflect.Type) bool {
+	seen := make(map[reflect.Type]bool)
+	for ; typ.Kind() == reflect.Pointer; typ = typ.Elem() {
+		if seen[typ] {
+			return true
+		}
+		seen[typ] = true
+	}
+	return false
+}
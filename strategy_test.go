@@ -0,0 +1,218 @@
+package strconvx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace_StrategyCache(t *testing.T) {
+	ns := NewNamespace()
+	var b bool
+	_, err := ns.New(&b)
+	assert.NoError(t, err)
+	_, ok := ns.strategies.Load().lookup(typeOf[*bool](), 0)
+	assert.True(t, ok)
+
+	// The options which don't affect the resolution share the same strategy.
+	_, err = ns.New(&b, Separator(";"))
+	assert.NoError(t, err)
+	assert.Len(t, *ns.strategies.Load(), 1)
+	_, ok = ns.strategies.Load().lookup(typeOf[*bool](), uint8(optionNoHybrid))
+	assert.False(t, ok)
+
+	_, err = ns.New(&b, NoHybrid())
+	assert.NoError(t, err)
+	_, ok = ns.strategies.Load().lookup(typeOf[*bool](), uint8(optionNoHybrid))
+	assert.True(t, ok)
+}
+
+func TestNamespace_StrategyCache_Error(t *testing.T) {
+	ns := NewNamespace()
+	var ch chan int
+	_, err := ns.New(&ch)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = ns.New(&ch)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	// The cached error is invalidated as well.
+	ns.Adapt(ToAnyAdaptor(func(v *chan int) (StringCodec, error) {
+		return formatterFunc(func() (string, error) { return "chan", nil }), nil
+	}))
+	sv, err := ns.New(&ch)
+	assert.NoError(t, err)
+	assert.Equal(t, "chan", must(sv.ToString()))
+}
+
+func TestNamespace_StrategyCache_Invalidation(t *testing.T) {
+	yesno := func(v *bool) (StringCodec, error) { return (*YesNo)(v), nil }
+
+	testcases := []struct {
+		name   string
+		change func(ns *Namespace)
+	}{
+		{"Adapt", func(ns *Namespace) { ns.Adapt(ToAnyAdaptor(yesno)) }},
+		{"AdaptKind", func(ns *Namespace) {
+			ns.AdaptKind(reflect.Bool, func(v any) (StringCodec, error) { return yesno(v.(*bool)) })
+		}},
+		{"AdaptFunc", func(ns *Namespace) {
+			ns.AdaptFunc(func(typ reflect.Type) bool { return typ.Kind() == reflect.Bool },
+				func(v any) (StringCodec, error) { return yesno(v.(*bool)) })
+		}},
+	}
+
+	for _, c := range testcases {
+		t.Run(c.name, func(t *testing.T) {
+			parent := NewNamespace()
+			child := parent.Derive()
+			b := true
+
+			for _, ns := range []*Namespace{parent, child} {
+				sv, err := ns.New(&b)
+				assert.NoError(t, err)
+				assert.Equal(t, "true", must(sv.ToString()))
+			}
+
+			// The changes of the parent invalidate the strategies cached in
+			// the child as well.
+			c.change(parent)
+			for _, ns := range []*Namespace{parent, child} {
+				sv, err := ns.New(&b)
+				assert.NoError(t, err)
+				assert.Equal(t, "yes", must(sv.ToString()))
+			}
+		})
+	}
+}
+
+func TestNamespace_StrategyCache_InvalidationOfContainedTypes(t *testing.T) {
+	ns := NewNamespace()
+	flags := []bool{true, false}
+	sv, err := ns.New(&flags)
+	assert.NoError(t, err)
+	assert.Equal(t, "true,false", must(sv.ToString()))
+
+	ns.Adapt(ToAnyAdaptor(func(v *bool) (StringCodec, error) { return (*YesNo)(v), nil }))
+	sv, err = ns.New(&flags)
+	assert.NoError(t, err)
+	assert.Equal(t, "yes,no", must(sv.ToString()))
+
+	ns.UndoAdapt(typeOf[bool]())
+	sv, err = ns.New(&flags)
+	assert.NoError(t, err)
+	assert.Equal(t, "true,false", must(sv.ToString()))
+}
+
+func TestNamespace_StrategyCache_AdaptInterface(t *testing.T) {
+	ns := NewNamespace()
+	color := Color(1)
+	sv, err := ns.New(&color, NoHybrid())
+	assert.NoError(t, err)
+	assert.Equal(t, "1", must(sv.ToString()))
+
+	ns.AdaptInterface(typeOf[Enum](), enumAdaptor)
+	sv, err = ns.New(&color, NoHybrid())
+	assert.NoError(t, err)
+	assert.Equal(t, "green", must(sv.ToString()))
+
+	ns.UndoAdaptInterface(typeOf[Enum]())
+	sv, err = ns.New(&color, NoHybrid())
+	assert.NoError(t, err)
+	assert.Equal(t, "1", must(sv.ToString()))
+}
+
+func benchmarkNew(b *testing.B, v any) {
+	ns := NewNamespace()
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ns.New(v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// Resolve the strategies of the type and the types it contains on every
+	// call, which is how New worked before the strategies were cached.
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ns.strategies.Store(nil)
+			if _, err := ns.New(v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestNamespace_New_NoAllocs(t *testing.T) {
+	ns := NewNamespace()
+	var v int
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := ns.New(&v); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs)
+
+	// The default options of a child don't affect its parent.
+	ns.Derive().SetOptions(IntBase(16))
+	allocs = testing.AllocsPerRun(100, func() {
+		if _, err := ns.New(&v); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkNamespace_New_Builtin(b *testing.B) {
+	var v int
+	benchmarkNew(b, &v)
+
+	// Look up the adaptors on every call, which is all New did for the builtin
+	// types before the strategies were cached, without allocating the options
+	// as it did. The cached New should be at least as fast as it.
+	b.Run("baseline", func(b *testing.B) {
+		b.ReportAllocs()
+		ns := NewNamespace()
+		var p any = &v
+		for i := 0; i < b.N; i++ {
+			rv := reflect.ValueOf(p)
+			if rv.Kind() != reflect.Pointer || rv.IsNil() {
+				b.Fatal("not a non-nil pointer")
+			}
+			baseType := rv.Type().Elem()
+			if _, ok := ns.adaptors[baseType]; ok {
+				b.Fatal("unexpected custom adaptor")
+			}
+			if _, err := builtinAdaptors[baseType](rv.Interface(), noOptions); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNamespace_New_Hybrid(b *testing.B) {
+	var v TextMarshalerAndUnmarshalerOrange
+	benchmarkNew(b, &v)
+}
+
+func BenchmarkNamespace_New_Named(b *testing.B) {
+	type UserID int64
+	var v UserID
+	benchmarkNew(b, &v)
+}
+
+func BenchmarkNamespace_New_Slice(b *testing.B) {
+	var v []int
+	benchmarkNew(b, &v)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
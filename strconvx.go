@@ -1,6 +1,12 @@
 // strconvx is a tiny package that helps converting values from/to text.
 package strconvx
 
+import (
+	"time"
+
+	"github.com/ggicci/strconvx/internal"
+)
+
 // StringMarshaler is implemented by types that can represent themselves as a string.
 // It is similar in spirit to encoding.TextMarshaler, but returns a string directly.
 type StringMarshaler interface {
@@ -16,6 +22,16 @@ type StringUnmarshaler interface {
 	FromString(string) error
 }
 
+// StringAppender is implemented by types that can append their string
+// representation to a byte slice, which saves the allocation of the string
+// returned by StringMarshaler.ToString. All the StringCodecs of the builtin
+// types implement it, see Append.
+type StringAppender interface {
+	// AppendString appends the string representation of the value to dst and
+	// returns the extended buffer.
+	AppendString(dst []byte) ([]byte, error)
+}
+
 // StringCodec is implemented by types that support bidirectional conversion
 // between their value and a string representation.
 //
@@ -25,6 +41,15 @@ type StringCodec interface {
 	StringUnmarshaler
 }
 
+// Default returns the default namespace, which is used by the package-level
+// functions, e.g. New and Parse, and by BindFlags and SQLValue given a nil
+// namespace. Don't register adaptors in it, since that changes the
+// conversions for every user of this package, derive a child namespace
+// instead, see Namespace.Derive.
+func Default() *Namespace {
+	return defaultNS
+}
+
 // New creates a StringCodec instance for the given value.
 // This is a shortcut to the default namespace's New method.
 // Note: since this uses the default namespace, it does not support
@@ -33,3 +58,147 @@ type StringCodec interface {
 func New(v any) (StringCodec, error) {
 	return defaultNS.New(v)
 }
+
+// Parse converts the string s to a value of type T.
+// This is a shortcut to ParseIn with the default namespace.
+//
+// Example:
+//
+//	id, err := strconvx.Parse[int64]("1024")
+//	tags, err := strconvx.Parse[[]string]("a,b,c")
+func Parse[T any](s string, opts ...Option) (T, error) {
+	return ParseIn[T](defaultNS, s, opts...)
+}
+
+// ParseIn converts the string s to a value of type T with the StringCodec
+// created by the given namespace, see Namespace.New.
+func ParseIn[T any](ns *Namespace, s string, opts ...Option) (T, error) {
+	var v T
+	codec, err := ns.New(&v, opts...)
+	if err != nil {
+		return v, err
+	}
+	if err := codec.FromString(s); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// Format converts the value v of type T to a string.
+// This is a shortcut to FormatIn with the default namespace.
+//
+// Example:
+//
+//	s, err := strconvx.Format(90 * time.Minute) // "1h30m0s"
+func Format[T any](v T, opts ...Option) (string, error) {
+	return FormatIn(defaultNS, v, opts...)
+}
+
+// FormatIn converts the value v of type T to a string with the StringCodec
+// created by the given namespace, see Namespace.New.
+func FormatIn[T any](ns *Namespace, v T, opts ...Option) (string, error) {
+	codec, err := ns.New(&v, opts...)
+	if err != nil {
+		return "", err
+	}
+	return codec.ToString()
+}
+
+// Append appends the string representation of the value v of type T to dst.
+// This is a shortcut to AppendIn with the default namespace.
+//
+// Example:
+//
+//	buf = append(buf, "user:"...)
+//	buf, err = strconvx.Append(buf, userID) // "user:1024"
+func Append[T any](dst []byte, v T, opts ...Option) ([]byte, error) {
+	return AppendIn(defaultNS, dst, v, opts...)
+}
+
+// AppendIn appends the string representation of the value v of type T to dst
+// with the StringCodec created by the given namespace, see Namespace.New. It
+// uses the AppendString method of the StringCodec if it implements
+// StringAppender, and falls back to ToString otherwise.
+//
+// The builtin types, e.g. int, float64 and time.Time, are appended without
+// allocations, as long as neither options nor adaptors apply to them.
+func AppendIn[T any](ns *Namespace, dst []byte, v T, opts ...Option) ([]byte, error) {
+	if len(opts) == 0 && ns.usesBuiltin(typeOf[T]()) {
+		if dst, ok := appendBuiltin(dst, v); ok {
+			return dst, nil
+		}
+	}
+	return appendWithCodec(ns, dst, v, opts...)
+}
+
+// appendWithCodec is the slow path of AppendIn. It's separated to keep v from
+// escaping to the heap in the fast path.
+func appendWithCodec[T any](ns *Namespace, dst []byte, v T, opts ...Option) ([]byte, error) {
+	codec, err := ns.New(&v, opts...)
+	if err != nil {
+		return dst, err
+	}
+	return appendString(dst, codec)
+}
+
+func appendString(dst []byte, m StringMarshaler) ([]byte, error) {
+	if appender, ok := m.(StringAppender); ok {
+		return appender.AppendString(dst)
+	}
+	s, err := m.ToString()
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, s...), nil
+}
+
+// appendBuiltin appends v with the builtin StringCodec of its type, without
+// creating the StringCodec. It returns false if T isn't a builtin type.
+func appendBuiltin[T any](dst []byte, v T) ([]byte, bool) {
+	var err error
+	switch x := any(v).(type) {
+	case string:
+		dst, err = internal.String(x).AppendString(dst)
+	case bool:
+		dst, err = internal.Bool(x).AppendString(dst)
+	case int:
+		dst, err = internal.Int(x).AppendString(dst)
+	case int8:
+		dst, err = internal.Int8(x).AppendString(dst)
+	case int16:
+		dst, err = internal.Int16(x).AppendString(dst)
+	case int32:
+		dst, err = internal.Int32(x).AppendString(dst)
+	case int64:
+		dst, err = internal.Int64(x).AppendString(dst)
+	case uint:
+		dst, err = internal.Uint(x).AppendString(dst)
+	case uint8:
+		dst, err = internal.Uint8(x).AppendString(dst)
+	case uint16:
+		dst, err = internal.Uint16(x).AppendString(dst)
+	case uint32:
+		dst, err = internal.Uint32(x).AppendString(dst)
+	case uint64:
+		dst, err = internal.Uint64(x).AppendString(dst)
+	case float32:
+		dst, err = internal.Float32(x).AppendString(dst)
+	case float64:
+		dst, err = internal.Float64(x).AppendString(dst)
+	case complex64:
+		dst, err = internal.Complex64(x).AppendString(dst)
+	case complex128:
+		dst, err = internal.Complex128(x).AppendString(dst)
+	case time.Time:
+		dst, err = internal.Time(x).AppendString(dst)
+	case time.Duration:
+		dst, err = internal.Duration(x).AppendString(dst)
+	case []byte:
+		dst, err = internal.ByteSlice(x).AppendString(dst)
+	default:
+		return dst, false
+	}
+	// The builtin StringCodecs never fail to append.
+	return dst, err == nil
+}
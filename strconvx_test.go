@@ -2,7 +2,9 @@ package strconvx
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -116,6 +118,333 @@ func TestNew_Time(t *testing.T) {
 	assert.Error(t, sv.FromString("hello"))
 }
 
+func TestNew_TimeWithOptions(t *testing.T) {
+	shanghai := time.FixedZone("Asia/Shanghai", +8*3600)
+	var tm time.Time
+	sv, err := NewNamespace().New(&tm,
+		TimeLayouts(time.DateTime, time.RFC3339),
+		TimeFormat(time.RFC1123Z),
+		TimeLocation(shanghai),
+	)
+	assert.NoError(t, err)
+
+	// The zone-less input is in the given location.
+	assert.NoError(t, sv.FromString("1991-11-10 08:00:00"))
+	assert.True(t, time.Date(1991, 11, 10, 0, 0, 0, 0, time.UTC).Equal(tm))
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "Sun, 10 Nov 1991 08:00:00 +0800", got)
+	buf, err := sv.(StringAppender).AppendString(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, got, string(buf))
+
+	assert.NoError(t, sv.FromString("1991-11-10T00:00:00Z"))
+	assert.Equal(t, "Sun, 10 Nov 1991 08:00:00 +0800", must(sv.ToString()))
+
+	// The default layouts are replaced.
+	assert.Error(t, sv.FromString("1991-11-10"))
+	// Unix timestamps are still accepted.
+	assert.NoError(t, sv.FromString("678088800"))
+	assert.True(t, time.Date(1991, 6, 28, 6, 0, 0, 0, time.UTC).Equal(tm))
+}
+
+func TestNew_TimeWithUnixPrecision(t *testing.T) {
+	expected := time.Date(2021, 4, 21, 3, 15, 33, 284000000, time.UTC)
+	var tm time.Time
+	sv, err := NewNamespace().New(&tm, UnixPrecision(time.Millisecond))
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("1618974933284"))
+	assert.Equal(t, expected, tm)
+	assert.NoError(t, sv.FromString("1618974933284.5"))
+	assert.Equal(t, expected.Add(500*time.Microsecond), tm)
+	assert.Equal(t, "2021-04-21T03:15:33.2845Z", must(sv.ToString()))
+
+	sv, err = NewNamespace().New(&tm, AutoUnixPrecision())
+	assert.NoError(t, err)
+	for _, s := range []string{"1618974933", "1618974933284", "1618974933284000", "1618974933284000000"} {
+		assert.NoError(t, sv.FromString(s))
+		assert.Equal(t, expected.Truncate(time.Second), tm.Truncate(time.Second), s)
+	}
+	assert.Equal(t, expected, tm)
+	assert.NoError(t, sv.FromString("1618974933.284"))
+	assert.Equal(t, expected, tm)
+
+	// The layouts are still accepted.
+	assert.NoError(t, sv.FromString("2021-04-21"))
+	assert.Equal(t, time.Date(2021, 4, 21, 0, 0, 0, 0, time.UTC), tm)
+	assert.Error(t, sv.FromString("99999999999999999999"))
+	assert.Error(t, sv.FromString("hello"))
+}
+
+func TestNew_InvalidUnixPrecision(t *testing.T) {
+	for _, unit := range []time.Duration{time.Minute, 2 * time.Second, 10 * time.Millisecond, -time.Second} {
+		var tm time.Time
+		sv, err := NewNamespace().New(&tm, UnixPrecision(unit))
+		assert.ErrorContains(t, err, "invalid unix precision", unit)
+		assert.Nil(t, sv)
+	}
+	_, err := Parse[time.Time]("60", UnixPrecision(time.Minute))
+	assert.ErrorContains(t, err, "invalid unix precision: 1m0s")
+}
+
+func TestNamespace_SetOptions_Time(t *testing.T) {
+	ns := NewNamespace()
+	ns.SetOptions(AutoUnixPrecision())
+	tm, err := ParseIn[time.Time](ns, "1618974933284")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 4, 21, 3, 15, 33, 284000000, time.UTC), tm)
+
+	// The default codec is kept without the time options.
+	var now time.Time
+	sv, err := New(&now)
+	assert.NoError(t, err)
+	assert.IsType(t, (*internal.Time)(nil), sv)
+}
+
+func TestNew_IntBase(t *testing.T) {
+	testcases := []struct {
+		input    string
+		expected int64
+	}{
+		{"0x1F", 31},
+		{"0X1f", 31},
+		{"0o755", 493},
+		{"0755", 493},
+		{"0b1010", 10},
+		{"1_000_000", 1000000},
+		{"-0x10", -16},
+		{"42", 42},
+	}
+	for _, c := range testcases {
+		v, err := Parse[int64](c.input, IntBase(0))
+		assert.NoError(t, err, c.input)
+		assert.Equal(t, c.expected, v, c.input)
+	}
+
+	_, err := Parse[int64]("0x1F")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = Parse[int64]("1__0", IntBase(0))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	v, err := Parse[uint16]("ff", IntBase(16))
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(255), v)
+}
+
+func TestNew_IntFormatBase(t *testing.T) {
+	testcases := []struct {
+		value    any
+		base     int
+		expected string
+	}{
+		{int(31), 16, "0x1f"},
+		{int8(-16), 16, "-0x10"},
+		{int64(math.MinInt64), 16, "-0x8000000000000000"},
+		{uint8(5), 2, "0b101"},
+		{uint32(493), 8, "0o755"},
+		{uint64(35), 36, "z"},
+		{int16(-35), 36, "-z"},
+	}
+	for _, c := range testcases {
+		rv := reflect.New(reflect.TypeOf(c.value))
+		rv.Elem().Set(reflect.ValueOf(c.value))
+		sv, err := NewNamespace().New(rv, IntFormatBase(c.base))
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, must(sv.ToString()), "%T", c.value)
+		buf, err := sv.(StringAppender).AppendString(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, string(buf))
+
+		// Round trip with IntBase(0), except for the bases without prefixes.
+		if c.base != 36 {
+			sv, err := NewNamespace().New(rv, IntBase(0))
+			assert.NoError(t, err)
+			assert.NoError(t, sv.FromString(c.expected))
+			assert.Equal(t, c.value, rv.Elem().Interface())
+
+			// The same codec parses its output back.
+			rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+			sv, err = NewNamespace().New(rv, IntFormatBase(c.base))
+			assert.NoError(t, err)
+			assert.NoError(t, sv.FromString(c.expected))
+			assert.Equal(t, c.value, rv.Elem().Interface())
+		}
+	}
+
+	// The decimal input is still accepted, and the prefixes of the other
+	// bases aren't.
+	var n int
+	sv, err := NewNamespace().New(&n, IntFormatBase(16))
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("010"))
+	assert.Equal(t, 10, n)
+	assert.NoError(t, sv.FromString("0X1F"))
+	assert.Equal(t, 31, n)
+	assert.Error(t, sv.FromString("0b101"))
+	assert.Error(t, sv.FromString("1f"))
+
+	n, err = Parse[int]("0x1f", IntFormatBase(16))
+	assert.NoError(t, err)
+	assert.Equal(t, 31, n)
+
+	var i int
+	_, err = NewNamespace().New(&i, IntFormatBase(1))
+	assert.ErrorContains(t, err, "invalid integer format base")
+	_, err = NewNamespace().New(&i, IntBase(37))
+	assert.ErrorContains(t, err, "invalid integer base")
+}
+
+func TestNew_ClampOverflow(t *testing.T) {
+	i8, err := Parse[int8]("300", ClampOverflow())
+	assert.NoError(t, err)
+	assert.Equal(t, int8(127), i8)
+	i8, err = Parse[int8]("-300", ClampOverflow())
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), i8)
+	i64, err := Parse[int64]("99999999999999999999", ClampOverflow())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), i64)
+
+	u8, err := Parse[uint8]("256", ClampOverflow())
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+	u, err := Parse[uint]("-1", ClampOverflow())
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), u)
+	u, err = Parse[uint]("-0x10", ClampOverflow(), IntBase(0))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), u)
+
+	// Syntax errors are still reported.
+	_, err = Parse[uint]("-abc", ClampOverflow())
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = Parse[int8]("abc", ClampOverflow())
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	// The error policy by default.
+	_, err = Parse[int8]("300")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func TestNew_IntOptions_NamedType(t *testing.T) {
+	type Flags uint32
+	ns := NewNamespace()
+	ns.SetOptions(IntBase(0), IntFormatBase(16))
+	f, err := ParseIn[Flags](ns, "0b1111")
+	assert.NoError(t, err)
+	assert.Equal(t, Flags(15), f)
+	s, err := FormatIn(ns, f)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf", s)
+
+	_, err = ParseIn[Flags](ns, "xyz")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[Flags](), ce.Type)
+}
+
+func TestNew_FloatFormat(t *testing.T) {
+	testcases := []struct {
+		value    any
+		format   byte
+		prec     int
+		expected string
+	}{
+		{float64(3.14159), 'f', 2, "3.14"},
+		{float64(2), 'f', 2, "2.00"},
+		{float64(1234.5), 'e', -1, "1.2345e+03"},
+		{float64(1234.5), 'E', 2, "1.23E+03"},
+		{float64(1e21), 'g', -1, "1e+21"},
+		{float32(0.1), 'g', -1, "0.1"},
+		{float32(1.5), 'f', 3, "1.500"},
+	}
+	for _, c := range testcases {
+		rv := reflect.New(reflect.TypeOf(c.value))
+		rv.Elem().Set(reflect.ValueOf(c.value))
+		sv, err := NewNamespace().New(rv, FloatFormat(c.format, c.prec))
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, must(sv.ToString()))
+		buf, err := sv.(StringAppender).AppendString(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, string(buf))
+	}
+
+	var f float64
+	_, err := NewNamespace().New(&f, FloatFormat('z', 2))
+	assert.ErrorContains(t, err, "invalid float format")
+}
+
+func TestNew_RejectNonFinite(t *testing.T) {
+	for _, s := range []string{"NaN", "Inf", "+Inf", "-inf", "infinity"} {
+		_, err := Parse[float64](s)
+		assert.NoError(t, err, s)
+
+		_, err = Parse[float64](s, RejectNonFinite())
+		assert.ErrorIs(t, err, ErrNonFinite, s)
+		_, err = Parse[float32](s, RejectNonFinite())
+		assert.ErrorIs(t, err, ErrNonFinite, s)
+	}
+
+	f, err := Parse[float32]("1.5", RejectNonFinite())
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1.5), f)
+	_, err = Parse[float32]("1e39", RejectNonFinite())
+	assert.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func TestNamespace_SetOptions_Float(t *testing.T) {
+	type Price float64
+	ns := NewNamespace()
+	ns.SetOptions(FloatFormat('f', 2), RejectNonFinite())
+	s, err := FormatIn(ns, []Price{1, 2.5, 3.14159})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.00,2.50,3.14", s)
+
+	_, err = ParseIn[Price](ns, "NaN")
+	var ce *ConvError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, typeOf[Price](), ce.Type)
+	assert.ErrorIs(t, err, ErrNonFinite)
+}
+
+func TestNew_Duration(t *testing.T) {
+	var d = 90 * time.Minute
+	sv, err := New(&d)
+	assert.NoError(t, err)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1h30m0s", got)
+
+	assert.NoError(t, sv.FromString("300ms"))
+	assert.Equal(t, 300*time.Millisecond, d)
+	assert.NoError(t, sv.FromString("0"))
+	assert.Equal(t, time.Duration(0), d)
+
+	// Plain integers are not accepted without a unit.
+	assert.Error(t, sv.FromString("30"))
+	assert.Error(t, sv.FromString("hello"))
+}
+
+func TestNew_DurationWithUnit(t *testing.T) {
+	var d time.Duration
+	sv, err := NewNamespace().New(&d, DurationUnit(time.Second))
+	assert.NoError(t, err)
+
+	assert.NoError(t, sv.FromString("30"))
+	assert.Equal(t, 30*time.Second, d)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "30s", got)
+
+	assert.NoError(t, sv.FromString("1h30m"))
+	assert.Equal(t, 90*time.Minute, d)
+	assert.NoError(t, sv.FromString("-5"))
+	assert.Equal(t, -5*time.Second, d)
+
+	assert.ErrorIs(t, sv.FromString("9223372036854775807"), strconv.ErrRange)
+	assert.Error(t, sv.FromString("hello"))
+}
+
 func TestNew_ByteSlice(t *testing.T) {
 	var b []byte = []byte("hello")
 	rvByteSlice := reflect.ValueOf(b)
@@ -136,6 +465,33 @@ func TestNew_ByteSlice(t *testing.T) {
 	assert.Error(t, sv.FromString("hello"))
 }
 
+func TestNew_NamedBuiltinType(t *testing.T) {
+	type UserID int64
+	var id UserID = 1024
+	sv, err := New(&id)
+	assert.NoError(t, err)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1024", got)
+	assert.NoError(t, sv.FromString("2048"))
+	assert.Equal(t, UserID(2048), id)
+	assert.Error(t, sv.FromString("hello"))
+
+	type Name string
+	var name Name
+	sv, err = New(&name)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("ggicci"))
+	assert.Equal(t, Name("ggicci"), name)
+
+	type Hash []byte
+	var hash Hash
+	sv, err = New(&hash)
+	assert.NoError(t, err)
+	assert.NoError(t, sv.FromString("aGVsbG8="))
+	assert.Equal(t, Hash("hello"), hash)
+}
+
 type StructNotStringConvertable struct {
 	Name string
 }
@@ -168,6 +524,188 @@ func TestNew_ErrUnsupportedType(t *testing.T) {
 	assert.Nil(t, sv)
 }
 
+func TestParse(t *testing.T) {
+	id, err := Parse[int64]("1024")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), id)
+
+	id, err = Parse[int64]("hello")
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), id)
+
+	ids, err := Parse[[]int]("1;2;3", Separator(";"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	p, err := Parse[*int]("10")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, *p)
+
+	yn, err := Parse[YesNo]("yes")
+	assert.NoError(t, err)
+	assert.Equal(t, YesNo(true), yn)
+
+	_, err = Parse[StructNotStringConvertable]("hello")
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestParseIn(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	b, err := ParseIn[bool](ns, "yes")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = ParseIn[bool](ns, "true")
+	assert.ErrorContains(t, err, "invalid value")
+}
+
+func TestFormat(t *testing.T) {
+	s, err := Format(90 * time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "1h30m0s", s)
+
+	s, err = Format(map[string]int{"b": 2, "a": 1}, KeyValueSeparator(":"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a:1,b:2", s)
+
+	s, err = Format(YesNo(false))
+	assert.NoError(t, err)
+	assert.Equal(t, "no", s)
+
+	_, err = Format(StructNotStringConvertable{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestFormatIn(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	s, err := FormatIn(ns, []bool{true, false})
+	assert.NoError(t, err)
+	assert.Equal(t, "yes,no", s)
+}
+
+func TestNew_StringAppender(t *testing.T) {
+	instances := append(getBuiltinInstances(),
+		complex(1.5, -2), complex(math.Inf(1), math.Inf(1)), complex(0, math.NaN()),
+		complex64(complex(-1, 0.25)), complex(0, math.Copysign(0, -1)),
+	)
+	for _, v := range instances {
+		rv := reflect.New(reflect.TypeOf(v))
+		rv.Elem().Set(reflect.ValueOf(v))
+		sv, err := New(rv)
+		assert.NoError(t, err)
+
+		appender, ok := sv.(StringAppender)
+		assert.True(t, ok, "%T should implement StringAppender", v)
+		expected, err := sv.ToString()
+		assert.NoError(t, err)
+		got, err := appender.AppendString([]byte("prefix:"))
+		assert.NoError(t, err)
+		assert.Equal(t, "prefix:"+expected, string(got), "%T", v)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	buf, err := Append([]byte("user:"), int64(1024))
+	assert.NoError(t, err)
+	assert.Equal(t, "user:1024", string(buf))
+
+	type UserID int64
+	buf, err = Append(buf, UserID(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, "user:1024-1", string(buf))
+
+	// Falls back to ToString.
+	buf, err = Append(nil, []int{1, 2}, Separator(";"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1;2", string(buf))
+
+	buf, err = Append([]byte("x"), StructNotStringConvertable{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Equal(t, "x", string(buf))
+}
+
+func TestAppendIn(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	buf, err := AppendIn(ns, []byte("enabled="), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "enabled=yes", string(buf))
+
+	// The adaptors and the options bypass the fast path of the builtin types.
+	ns.AdaptKind(reflect.Float64, func(v any) (StringCodec, error) {
+		return fixedFloat{reflect.ValueOf(v).Elem(), 2}, nil
+	})
+	buf, err = AppendIn(ns, nil, 3.14159)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14", string(buf))
+
+	child := ns.Derive()
+	child.SetOptions(IntFormatBase(16))
+	buf, err = AppendIn(child, nil, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1f", string(buf))
+	buf, err = AppendIn(NewNamespace(), nil, 31, IntFormatBase(16))
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1f", string(buf))
+}
+
+func TestAppend_Allocations(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	now := time.Now()
+	for name, appendFunc := range map[string]func() ([]byte, error){
+		"string":        func() ([]byte, error) { return Append(buf[:0], "hello") },
+		"bool":          func() ([]byte, error) { return Append(buf[:0], true) },
+		"int":           func() ([]byte, error) { return Append(buf[:0], 42) },
+		"uint8":         func() ([]byte, error) { return Append(buf[:0], uint8(42)) },
+		"float64":       func() ([]byte, error) { return Append(buf[:0], 3.14) },
+		"complex128":    func() ([]byte, error) { return Append(buf[:0], 1+2i) },
+		"time.Time":     func() ([]byte, error) { return Append(buf[:0], now) },
+		"time.Duration": func() ([]byte, error) { return Append(buf[:0], 90*time.Minute) },
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := appendFunc(); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Equal(t, 0.0, allocs, name)
+	}
+}
+
+func TestAppendString_Allocations(t *testing.T) {
+	var i int64 = 1024
+	f := 3.14
+	now := time.Now()
+	d := 90*time.Minute + 1500*time.Millisecond
+	codecs := []StringCodec{
+		(*internal.Int64)(&i),
+		(*internal.Float64)(&f),
+		(*internal.Time)(&now),
+		(*internal.Duration)(&d),
+		&internal.DurationWithUnit{Value: &d, Unit: time.Second},
+	}
+	buf := make([]byte, 0, 64)
+	for _, codec := range codecs {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = codec.(StringAppender).AppendString(buf[:0])
+		})
+		assert.Equal(t, 0.0, allocs, "%T", codec)
+	}
+}
+
 type Numeric interface {
 	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64 | complex64 | complex128
 }
@@ -221,6 +759,7 @@ func getBuiltinInstances() []any {
 		complex64(1.0),
 		complex128(1.0),
 		time.Now(),
+		time.Second,
 		[]byte("hello"),
 	}
 }
@@ -0,0 +1,76 @@
+package strconvx
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// TextCodec adapts a StringCodec to encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler and json.Unmarshaler, where the
+// value is represented as a JSON string. It's the reverse of the hybrid
+// instances, which adapt the encoding.TextMarshaler and
+// encoding.TextUnmarshaler to a StringCodec.
+//
+// With TextCodec, the adaptors registered in a namespace apply uniformly when
+// the value is serialized to JSON, or used as a JSON map key, or by any other
+// packages which support encoding.TextMarshaler.
+//
+// Example:
+//
+//	var b bool = true
+//	codec, err := ns.New(&b) // ns has an adaptor for bool, e.g. YesNo
+//	data, err := json.Marshal(strconvx.TextCodec{codec}) // "yes"
+//	err = json.Unmarshal([]byte(`"no"`), &strconvx.TextCodec{codec}) // b == false
+//
+// Note that a zero TextCodec can't be decoded into, since it has no
+// underlying StringCodec. So TextCodec can't be used as the key type of a
+// map to be decoded by encoding/json.
+type TextCodec struct {
+	StringCodec
+}
+
+func (tc TextCodec) MarshalText() ([]byte, error) {
+	if tc.StringCodec == nil {
+		return nil, fmt.Errorf("%w: no underlying StringCodec", ErrNilPointer)
+	}
+	s, err := tc.ToString()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func (tc TextCodec) UnmarshalText(text []byte) error {
+	if tc.StringCodec == nil {
+		return fmt.Errorf("%w: no underlying StringCodec", ErrNilPointer)
+	}
+	return tc.FromString(string(text))
+}
+
+func (tc TextCodec) MarshalJSON() ([]byte, error) {
+	text, err := tc.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON accepts a JSON string, and ignores a JSON null by convention.
+func (tc TextCodec) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return tc.UnmarshalText([]byte(s))
+}
+
+var (
+	_ encoding.TextMarshaler   = TextCodec{}
+	_ encoding.TextUnmarshaler = TextCodec{}
+	_ json.Marshaler           = TextCodec{}
+	_ json.Unmarshaler         = TextCodec{}
+)
@@ -0,0 +1,99 @@
+package strconvx
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func yesNoNamespace() *Namespace {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+	return ns
+}
+
+func TestTextCodec_Text(t *testing.T) {
+	var b bool = true
+	codec, err := yesNoNamespace().New(&b)
+	assert.NoError(t, err)
+	tc := TextCodec{codec}
+
+	text, err := tc.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", string(text))
+
+	assert.NoError(t, tc.UnmarshalText([]byte("no")))
+	assert.False(t, b)
+	assert.ErrorContains(t, tc.UnmarshalText([]byte("false")), "invalid value")
+}
+
+func TestTextCodec_JSON(t *testing.T) {
+	var b bool = true
+	codec, err := yesNoNamespace().New(&b)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(TextCodec{codec})
+	assert.NoError(t, err)
+	assert.Equal(t, `"yes"`, string(data))
+
+	assert.NoError(t, json.Unmarshal([]byte(`"no"`), &TextCodec{codec}))
+	assert.False(t, b)
+
+	// null is ignored
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &TextCodec{codec}))
+	assert.False(t, b)
+
+	// only JSON strings are accepted
+	assert.Error(t, json.Unmarshal([]byte(`true`), &TextCodec{codec}))
+	assert.ErrorContains(t, json.Unmarshal([]byte(`"maybe"`), &TextCodec{codec}), "invalid value")
+}
+
+func TestTextCodec_JSONStructAndMapKey(t *testing.T) {
+	ns := yesNoNamespace()
+	var (
+		enabled = true
+		timeout = 90 * time.Second
+		ids     = []int{1, 2}
+	)
+	codecs := make([]TextCodec, 3)
+	for i, v := range []any{&enabled, &timeout, &ids} {
+		codec, err := ns.New(v)
+		assert.NoError(t, err)
+		codecs[i] = TextCodec{codec}
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"enabled": codecs[0],
+		"timeout": codecs[1],
+		"ids":     codecs[2],
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"enabled":"yes","timeout":"1m30s","ids":"1,2"}`, string(data))
+
+	data, err = json.Marshal(map[TextCodec]int{codecs[0]: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"yes":1}`, string(data))
+
+	var doc struct {
+		Enabled TextCodec `json:"enabled"`
+		Timeout TextCodec `json:"timeout"`
+	}
+	doc.Enabled, doc.Timeout = codecs[0], codecs[1]
+	assert.NoError(t, json.Unmarshal([]byte(`{"enabled":"no","timeout":"1s"}`), &doc))
+	assert.False(t, enabled)
+	assert.Equal(t, time.Second, timeout)
+}
+
+func TestTextCodec_Zero(t *testing.T) {
+	var tc TextCodec
+	_, err := tc.MarshalText()
+	assert.ErrorIs(t, err, ErrNilPointer)
+	assert.ErrorIs(t, tc.UnmarshalText([]byte("x")), ErrNilPointer)
+	_, err = json.Marshal(tc)
+	assert.ErrorIs(t, err, ErrNilPointer)
+}
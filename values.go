@@ -0,0 +1,191 @@
+package strconvx
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+)
+
+// valuesTag is the struct tag used to customize the keys of the fields in
+// DecodeValues and EncodeValues, e.g. `strconvx:"page"`. The key of a field
+// without the tag is the name of the field, and the fields with the tag "-"
+// are skipped. EncodeValues also supports the "omitempty" option, e.g.
+// `strconvx:"page,omitempty"`.
+const valuesTag = "strconvx"
+
+// DecodeValues populates the fields of the struct dst points to with the
+// values in vals, e.g. a URL query. Each field is converted with the
+// StringCodec created by the namespace with the given options, see New. The
+// fields whose keys are absent in vals are left untouched.
+//
+// For a field of an unnamed slice type other than []byte, e.g. []int, each
+// value of the repeated key is converted to an element, e.g. "?id=1&id=2".
+// A single value is converted as a whole by the StringCodec of the slice
+// instead, i.e. split by the Separator, e.g. "?id=1,2". For other fields, only
+// the first value of the key is used.
+//
+// All the failed fields are reported at once in a FieldErrors.
+//
+// Example:
+//
+//	type ListUsersQuery struct {
+//		Page    int      `strconvx:"page"`
+//		Roles   []string `strconvx:"role"`
+//		Verbose bool     `strconvx:"-"`
+//	}
+//
+//	var q ListUsersQuery
+//	err := ns.DecodeValues(&q, r.URL.Query())
+func (c *Namespace) DecodeValues(dst any, vals url.Values, opts ...Option) error {
+	rv, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+
+	o := c.options(opts)
+	var errs FieldErrors
+	for _, field := range structFields(rv, valuesTag) {
+		key, _ := parseTag(field.StructField, valuesTag)
+		if key == "" || len(vals[key]) == 0 {
+			continue
+		}
+		values := vals[key]
+		if err := c.decodeField(field.Value, values, o); err != nil {
+			errs = append(errs, &FieldError{Field: field.Name, Key: key, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Namespace) decodeField(rv reflect.Value, values []string, opts *options) error {
+	if !c.isRepeatable(rv.Type()) || len(values) == 1 {
+		codec, err := c.createStringCodec(rv.Addr(), opts)
+		if err != nil {
+			return err
+		}
+		return codec.FromString(values[0])
+	}
+
+	slice := reflect.MakeSlice(rv.Type(), len(values), len(values))
+	for i, value := range values {
+		codec, err := c.createStringCodec(slice.Index(i).Addr(), opts)
+		if err != nil {
+			return err
+		}
+		if err := codec.FromString(value); err != nil {
+			return err
+		}
+	}
+	rv.Set(slice)
+	return nil
+}
+
+// EncodeValues is the reverse operation of DecodeValues. It converts the
+// fields of the struct src (or a pointer to it) to url.Values with the
+// StringCodecs created by the namespace with the given options, see New.
+//
+// For a field of an unnamed slice type other than []byte, e.g. []int, each
+// element is converted to a value of the repeated key. A single element is
+// converted by the StringCodec of the slice instead, which quotes it if it
+// contains the Separator, so that DecodeValues gets it back. A field with the
+// "omitempty" option is omitted if it's a zero value, or an empty slice or
+// map.
+//
+// All the failed fields are reported at once in a FieldErrors.
+//
+// Example:
+//
+//	type ListUsersQuery struct {
+//		Page  int      `strconvx:"page,omitempty"`
+//		Roles []string `strconvx:"role"`
+//	}
+//
+//	vals, err := ns.EncodeValues(ListUsersQuery{Roles: []string{"admin", "user"}})
+//	vals.Encode() // "role=admin&role=user"
+func (c *Namespace) EncodeValues(src any, opts ...Option) (url.Values, error) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, unsupportedType(reflect.TypeOf(src))
+	}
+	if !rv.CanAddr() {
+		// Make a copy, since the StringCodecs require pointers.
+		copied := reflect.New(rv.Type()).Elem()
+		copied.Set(rv)
+		rv = copied
+	}
+
+	o := c.options(opts)
+	vals := make(url.Values)
+	var errs FieldErrors
+	for _, field := range structFields(rv, valuesTag) {
+		key, tagOpts := parseTag(field.StructField, valuesTag)
+		if key == "" || (slices.Contains(tagOpts, "omitempty") && isEmptyValue(field.Value)) {
+			continue
+		}
+		values, err := c.encodeField(field.Value, o)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: field.Name, Key: key, Err: err})
+			continue
+		}
+		if len(values) > 0 {
+			vals[key] = values
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return vals, nil
+}
+
+func (c *Namespace) encodeField(rv reflect.Value, opts *options) ([]string, error) {
+	if !c.isRepeatable(rv.Type()) || rv.Len() == 1 {
+		codec, err := c.createStringCodec(rv.Addr(), opts)
+		if err != nil {
+			return nil, err
+		}
+		value, err := codec.ToString()
+		if err != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	}
+
+	values := make([]string, rv.Len())
+	for i := range values {
+		codec, err := c.createStringCodec(rv.Index(i).Addr(), opts)
+		if err != nil {
+			return nil, err
+		}
+		if values[i], err = codec.ToString(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// isRepeatable tells whether the values of the given type are represented by
+// repeated keys, i.e. an unnamed slice type other than []byte, which has no
+// custom adaptors, including the interface, kind and predicate adaptors.
+func (c *Namespace) isRepeatable(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice || typ.Name() != "" || typ == typeOf[[]byte]() {
+		return false
+	}
+	return c.lookupCustom(typ) == nil
+}
+
+// isEmptyValue tells whether the given value is a zero value, or an empty
+// slice or map, which is omitted by the "omitempty" option.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
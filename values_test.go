@@ -0,0 +1,289 @@
+package strconvx
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Pagination struct {
+	Page    int `strconvx:"page"`
+	PerPage int `strconvx:"per_page"`
+}
+
+type ListUsersQuery struct {
+	Pagination
+	Name      string        `strconvx:"name"`
+	Roles     []string      `strconvx:"role"`
+	IDs       []int64       `strconvx:"id"`
+	Tags      Tags          `strconvx:"tags"`
+	Active    *bool         `strconvx:"active"`
+	Timeout   time.Duration `strconvx:"timeout"`
+	Answer    YesNo         `strconvx:"answer"`
+	Token     []byte        `strconvx:"token"`
+	Internal  string        `strconvx:"-"`
+	NoTag     string
+	unexposed string
+}
+
+type Tags []string
+
+func TestNamespace_DecodeValues(t *testing.T) {
+	vals := url.Values{
+		"page":     {"2"},
+		"per_page": {"50", "100"},
+		"name":     {"ggicci"},
+		"role":     {"admin", "user,guest"},
+		"id":       {"1", "2", "3"},
+		"tags":     {"a,b"},
+		"active":   {"true"},
+		"timeout":  {"1m"},
+		"answer":   {"yes"},
+		"token":    {"aGVsbG8="},
+		"-":        {"x"},
+		"Internal": {"x"},
+		"NoTag":    {"hello"},
+	}
+
+	q := ListUsersQuery{Name: "untouched", unexposed: "untouched"}
+	assert.NoError(t, NewNamespace().DecodeValues(&q, vals))
+	assert.Equal(t, 2, q.Page)
+	assert.Equal(t, 50, q.PerPage, "use the first value")
+	assert.Equal(t, "ggicci", q.Name)
+	assert.Equal(t, []string{"admin", "user,guest"}, q.Roles)
+	assert.Equal(t, []int64{1, 2, 3}, q.IDs)
+	assert.Equal(t, Tags{"a", "b"}, q.Tags, "named slice types use their own StringCodecs")
+	assert.True(t, *q.Active)
+	assert.Equal(t, time.Minute, q.Timeout)
+	assert.Equal(t, YesNo(true), q.Answer)
+	assert.Equal(t, []byte("hello"), q.Token)
+	assert.Empty(t, q.Internal)
+	assert.Equal(t, "hello", q.NoTag)
+	assert.Equal(t, "untouched", q.unexposed)
+
+	// A single value of a slice field is split by the separator.
+	q = ListUsersQuery{}
+	assert.NoError(t, NewNamespace().DecodeValues(&q, url.Values{"id": {"4,5"}, "role": {`"user,guest"`}}))
+	assert.Equal(t, []int64{4, 5}, q.IDs)
+	assert.Equal(t, []string{"user,guest"}, q.Roles)
+
+	// Absent keys leave the fields untouched.
+	q = ListUsersQuery{Name: "untouched"}
+	assert.NoError(t, NewNamespace().DecodeValues(&q, url.Values{"page": {"1"}}))
+	assert.Equal(t, "untouched", q.Name)
+	assert.Equal(t, 1, q.Page)
+}
+
+func TestNamespace_DecodeValues_FieldErrors(t *testing.T) {
+	vals := url.Values{
+		"page":   {"hello"},
+		"id":     {"1", "x"},
+		"answer": {"maybe"},
+		"name":   {"ggicci"},
+	}
+
+	var q ListUsersQuery
+	err := NewNamespace().DecodeValues(&q, vals)
+	var fieldErrors FieldErrors
+	assert.ErrorAs(t, err, &fieldErrors)
+	assert.Len(t, fieldErrors, 3)
+	assert.Equal(t, "Page", fieldErrors[0].Field)
+	assert.Equal(t, "page", fieldErrors[0].Key)
+	assert.Equal(t, "IDs", fieldErrors[1].Field)
+	assert.Equal(t, "id", fieldErrors[1].Key)
+	assert.Equal(t, "Answer", fieldErrors[2].Field)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorContains(t, err, `field Page (page): cannot convert "hello" to int: invalid syntax; field IDs (id)`)
+	assert.Equal(t, "ggicci", q.Name, "the other fields are still decoded")
+
+	var fe *FieldError
+	assert.True(t, errors.As(err, &fe))
+	assert.Equal(t, "Page", fe.Field)
+}
+
+func TestNamespace_DecodeValues_CustomAdaptor(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyAdaptor(func(b *bool) (StringCodec, error) {
+		return (*YesNo)(b), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	var filter struct {
+		Active *bool  `strconvx:"active"`
+		Flags  []bool `strconvx:"flag"`
+	}
+	assert.NoError(t, ns.DecodeValues(&filter, url.Values{"active": {"no"}, "flag": {"yes", "no"}}))
+	assert.False(t, *filter.Active)
+	assert.Equal(t, []bool{true, false}, filter.Flags)
+}
+
+func TestNamespace_Values_SliceAdaptors(t *testing.T) {
+	type Filter struct {
+		IDs []int `strconvx:"id"`
+	}
+	joined := func(v any) (StringCodec, error) {
+		return NewNamespace().New(v, Separator("|"))
+	}
+
+	testcases := []struct {
+		name  string
+		adapt func(ns *Namespace)
+	}{
+		{"AdaptKind", func(ns *Namespace) { ns.AdaptKind(reflect.Slice, joined) }},
+		{"AdaptFunc", func(ns *Namespace) {
+			ns.AdaptFunc(func(typ reflect.Type) bool { return typ == typeOf[[]int]() }, joined)
+		}},
+		{"AdaptInterface", func(ns *Namespace) {
+			ns.AdaptInterface(typeOf[any](), func(v any) (StringCodec, error) {
+				if _, ok := v.(*[]int); ok {
+					return joined(v)
+				}
+				return NewNamespace().New(v)
+			})
+		}},
+	}
+
+	for _, c := range testcases {
+		t.Run(c.name, func(t *testing.T) {
+			ns := NewNamespace()
+			c.adapt(ns)
+
+			// The slice adaptors convert the slices as a whole, instead of
+			// repeating the keys.
+			vals, err := ns.EncodeValues(Filter{IDs: []int{1, 2}})
+			assert.NoError(t, err)
+			assert.Equal(t, url.Values{"id": {"1|2"}}, vals)
+
+			var f Filter
+			assert.NoError(t, ns.DecodeValues(&f, url.Values{"id": {"3|4"}}))
+			assert.Equal(t, []int{3, 4}, f.IDs)
+		})
+	}
+}
+
+func TestNamespace_DecodeValues_InvalidDestination(t *testing.T) {
+	ns := NewNamespace()
+	var q ListUsersQuery
+	assert.ErrorIs(t, ns.DecodeValues(q, url.Values{}), ErrNotPointer)
+	assert.ErrorIs(t, ns.DecodeValues((*ListUsersQuery)(nil), url.Values{}), ErrNilPointer)
+	var i int
+	assert.ErrorIs(t, ns.DecodeValues(&i, url.Values{}), ErrUnsupportedType)
+
+	var s struct {
+		Object StructNotStringConvertable `strconvx:"object"`
+	}
+	assert.ErrorIs(t, ns.DecodeValues(&s, url.Values{"object": {"x"}}), ErrUnsupportedType)
+}
+
+func TestNamespace_EncodeValues(t *testing.T) {
+	active := false
+	q := ListUsersQuery{
+		Pagination: Pagination{Page: 2},
+		Name:       "ggicci",
+		Roles:      []string{"admin", "user,guest"},
+		Tags:       Tags{"a", "b"},
+		Active:     &active,
+		Timeout:    time.Minute,
+		Answer:     true,
+		Token:      []byte("hello"),
+		Internal:   "secret",
+	}
+
+	vals, err := NewNamespace().EncodeValues(q)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"page":     {"2"},
+		"per_page": {"0"},
+		"name":     {"ggicci"},
+		"role":     {"admin", "user,guest"},
+		"tags":     {"a,b"},
+		"active":   {"false"},
+		"timeout":  {"1m0s"},
+		"answer":   {"yes"},
+		"token":    {"aGVsbG8="},
+		"NoTag":    {""},
+	}, vals)
+
+	// Pointers to structs are accepted, too.
+	decoded := ListUsersQuery{}
+	assert.NoError(t, NewNamespace().DecodeValues(&decoded, vals))
+	vals2, err := NewNamespace().EncodeValues(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, vals, vals2)
+
+	// A single element is quoted if it contains the separator, so that it
+	// isn't split by DecodeValues.
+	q = ListUsersQuery{Roles: []string{"user,guest"}, IDs: []int64{1}}
+	vals, err = NewNamespace().EncodeValues(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`"user,guest"`}, vals["role"])
+	assert.Equal(t, []string{"1"}, vals["id"])
+	decoded = ListUsersQuery{}
+	assert.NoError(t, NewNamespace().DecodeValues(&decoded, vals))
+	assert.Equal(t, q.Roles, decoded.Roles)
+	assert.Equal(t, q.IDs, decoded.IDs)
+}
+
+func TestNamespace_EncodeValues_OmitEmpty(t *testing.T) {
+	type Filter struct {
+		Page   int               `strconvx:"page,omitempty"`
+		Name   string            `strconvx:"name,omitempty"`
+		IDs    []int             `strconvx:"id,omitempty"`
+		Labels map[string]string `strconvx:"labels,omitempty"`
+		Active *bool             `strconvx:"active,omitempty"`
+		Sort   string            `strconvx:"sort"`
+		Limit  *int              `strconvx:"limit"`
+	}
+
+	vals, err := NewNamespace().EncodeValues(Filter{IDs: []int{}})
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"sort": {""}, "limit": {""}}, vals)
+
+	active := true
+	vals, err = NewNamespace().EncodeValues(Filter{
+		Page:   1,
+		IDs:    []int{1, 2},
+		Labels: map[string]string{"env": "prod"},
+		Active: &active,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"page":   {"1"},
+		"id":     {"1", "2"},
+		"labels": {"env=prod"},
+		"active": {"true"},
+		"sort":   {""},
+		"limit":  {""},
+	}, vals)
+	assert.Equal(t, "active=true&id=1&id=2&labels=env%3Dprod&limit=&page=1&sort=", vals.Encode())
+}
+
+func TestNamespace_EncodeValues_FieldErrors(t *testing.T) {
+	var s struct {
+		Banana     TextUnmarshalerBanana      `strconvx:"banana"`
+		Object     StructNotStringConvertable `strconvx:"object"`
+		Watermelon TextMarshalerSpoiledWatermelon
+		Name       string `strconvx:"name"`
+	}
+	vals, err := NewNamespace().EncodeValues(&s)
+	assert.Nil(t, vals)
+	var fieldErrors FieldErrors
+	assert.ErrorAs(t, err, &fieldErrors)
+	assert.Len(t, fieldErrors, 3)
+	assert.ErrorIs(t, fieldErrors[0], ErrNotStringMarshaler)
+	assert.ErrorIs(t, fieldErrors[1], ErrUnsupportedType)
+	assert.ErrorContains(t, fieldErrors[2], "spoiled")
+}
+
+func TestNamespace_EncodeValues_InvalidSource(t *testing.T) {
+	ns := NewNamespace()
+	_, err := ns.EncodeValues(1)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = ns.EncodeValues((*ListUsersQuery)(nil))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}